@@ -0,0 +1,2185 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"sshapp/internal/audit"
+	"sshapp/internal/bundle"
+	"sshapp/internal/capture"
+	"sshapp/internal/checksum"
+	"sshapp/internal/clipboard"
+	"sshapp/internal/cloud"
+	"sshapp/internal/editsync"
+	"sshapp/internal/filewatch"
+	"sshapp/internal/format"
+	"sshapp/internal/fuzzy"
+	"sshapp/internal/gitsync"
+	"sshapp/internal/guard"
+	"sshapp/internal/health"
+	"sshapp/internal/history"
+	"sshapp/internal/journal"
+	"sshapp/internal/layout"
+	"sshapp/internal/logtail"
+	"sshapp/internal/metrics"
+	"sshapp/internal/notes"
+	"sshapp/internal/pipeline"
+	"sshapp/internal/poller"
+	"sshapp/internal/portforward"
+	"sshapp/internal/portscan"
+	"sshapp/internal/procman"
+	"sshapp/internal/profile"
+	"sshapp/internal/redact"
+	"sshapp/internal/remotefs"
+	"sshapp/internal/savedcmd"
+	"sshapp/internal/scheduler"
+	"sshapp/internal/selfupdate"
+	"sshapp/internal/sendto"
+	"sshapp/internal/sessionstore"
+	"sshapp/internal/sshsession"
+	"sshapp/internal/suggest"
+	"sshapp/internal/svcmgr"
+	"sshapp/internal/template"
+	"sshapp/internal/textsync"
+	"sshapp/internal/transfer"
+	"sshapp/internal/vault"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// App is the Wails-bound application backend. All methods exposed to the
+// frontend hang off this struct. Each subsystem keeps its own
+// concurrency-safe store rather than sharing package-level state, so
+// multiple App instances (and their tests) never interfere with each
+// other.
+type App struct {
+	ctx context.Context
+
+	mu            sync.Mutex
+	profiles      *profile.Store
+	activity      *profile.ActivityStore
+	sessions      *sessionstore.Store
+	scheduler     *scheduler.Scheduler
+	savedCmds     *savedcmd.Store
+	cmdStats      *savedcmd.StatsStore
+	layouts       *layout.Store
+	sqliteHistory *history.SQLiteStore
+	healthPoller  *poller.Poller
+	configWatcher *poller.Poller
+	metricsPoller *poller.Poller
+	suggestions   *suggest.Cache
+	historyPins   *history.PinStore
+	transfers     *transfer.Manager
+	transferAudit map[string]transferAuditMeta
+	editSessions  map[string]chan struct{}
+	pathWatches   map[string]chan struct{}
+	logTails      map[string]chan struct{}
+	bookmarks     *remotefs.BookmarkStore
+	forwardMgrs   map[string]*portforward.Manager
+	forwardNextID int
+	savedForwards *portforward.ForwardStore
+	vault         *vault.Vault
+
+	pasteEndpoint string
+	pasteAPIToken string
+
+	version         string
+	releaseChannel  selfupdate.Channel
+	formatOptions   format.Options
+	historyMaxLines int
+	redactionRules  []redact.Rule
+}
+
+// appVersion is the build's semantic version, normally stamped in by the
+// release build script via -ldflags.
+var appVersion = "dev"
+
+// transferAuditMeta is the per-job context transfer.Progress itself
+// doesn't carry (just an opaque ID), kept around long enough for the
+// Manager's onProgress callback to audit-log a completed upload.
+type transferAuditMeta struct {
+	host string
+	path string
+}
+
+// schedulerRunner adapts App's sessions to scheduler.Runner.
+type schedulerRunner struct{ app *App }
+
+func (r schedulerRunner) Run(profileName, command string) (string, error) {
+	sess, err := r.app.sessionFor(profileName)
+	if err != nil {
+		return "", err
+	}
+	return sess.Run(command)
+}
+
+// auditCompletedTransfer records a completed upload tracked under id in
+// the audit log, if it was registered as one by UploadFiles or
+// TransferBetweenHosts.
+func (a *App) auditCompletedTransfer(id string) {
+	a.mu.Lock()
+	meta, ok := a.transferAudit[id]
+	delete(a.transferAudit, id)
+	a.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if err := audit.Record(audit.Entry{
+		Host:   meta.host,
+		Kind:   audit.KindUpload,
+		Detail: meta.path,
+		When:   time.Now(),
+	}); err != nil {
+		println("failed to record upload audit entry:", err.Error())
+	}
+}
+
+// NewApp constructs the backend. Heavier initialization (loading profiles
+// from disk, opening caches) happens in startup once the Wails context is
+// available.
+func NewApp() *App {
+	return &App{version: appVersion, releaseChannel: selfupdate.Stable}
+}
+
+func (a *App) startup(ctx context.Context) {
+	a.ctx = ctx
+
+	store, err := profile.NewStore("./configs")
+	if err != nil {
+		println("failed to load profiles:", err.Error())
+		store = profile.NewEmptyStore("./configs")
+	}
+	a.profiles = store
+
+	activity, err := profile.LoadActivityStore()
+	if err != nil {
+		println("failed to load profile activity:", err.Error())
+		activity = profile.NewEmptyActivityStore()
+	}
+	a.activity = activity
+
+	a.sessions = sessionstore.New()
+	a.scheduler = scheduler.New(schedulerRunner{app: a})
+	a.scheduler.OnResult(func(jobID, output string, err error) {
+		runtime.EventsEmit(a.ctx, "scheduler:result", jobID, output, fmt.Sprint(err))
+	})
+
+	savedCmds, err := savedcmd.Load()
+	if err != nil {
+		println("failed to load saved commands:", err.Error())
+		savedCmds = savedcmd.NewEmpty()
+	}
+	a.savedCmds = savedCmds
+
+	cmdStats, err := savedcmd.LoadStats()
+	if err != nil {
+		println("failed to load saved command stats:", err.Error())
+		cmdStats = savedcmd.NewEmptyStats()
+	}
+	a.cmdStats = cmdStats
+
+	layouts, err := layout.Load()
+	if err != nil {
+		println("failed to load session templates:", err.Error())
+		layouts = layout.NewEmpty()
+	}
+	a.layouts = layouts
+
+	sqliteHistory, err := history.OpenSQLiteStore()
+	if err != nil {
+		println("failed to open sqlite history store:", err.Error())
+	}
+	a.sqliteHistory = sqliteHistory
+
+	a.healthPoller = poller.New(30*time.Second, func() {
+		runtime.EventsEmit(a.ctx, "health:update", health.CheckAll(a.profiles.List()))
+	})
+
+	a.metricsPoller = poller.New(10*time.Second, func() {
+		runners := make(map[string]metrics.Runner)
+		for _, sess := range a.sessions.List() {
+			runners[sess.ID] = sess
+		}
+		runtime.EventsEmit(a.ctx, "metrics:update", metrics.CollectAll(runners))
+	})
+
+	// Profiles can also be added, edited, or removed outside the app
+	// (e.g. a dotfiles sync writing YAML directly into ./configs), so
+	// poll for that rather than requiring a restart to notice. It
+	// reuses the same "profile:changed" event the in-app CRUD methods
+	// already emit, since the UI shouldn't care which side caused the
+	// change.
+	a.configWatcher = poller.New(5*time.Second, func() {
+		changed, err := a.profiles.Reload()
+		if err != nil {
+			println("failed to reload profiles:", err.Error())
+			return
+		}
+		if changed {
+			runtime.EventsEmit(a.ctx, "profile:changed")
+		}
+	})
+	a.configWatcher.Start()
+
+	a.suggestions = suggest.NewCache()
+	a.redactionRules = redact.DefaultRules
+
+	pins, err := history.LoadPins()
+	if err != nil {
+		println("failed to load history pins:", err.Error())
+		pins = history.NewEmptyPins()
+	}
+	a.historyPins = pins
+
+	a.transferAudit = make(map[string]transferAuditMeta)
+	a.transfers = transfer.NewManager(3, func(p transfer.Progress) {
+		runtime.EventsEmit(a.ctx, "transfer:progress", p)
+		if p.State == transfer.Completed {
+			a.auditCompletedTransfer(p.ID)
+		}
+	})
+
+	a.editSessions = make(map[string]chan struct{})
+	a.pathWatches = make(map[string]chan struct{})
+	a.logTails = make(map[string]chan struct{})
+
+	bookmarks, err := remotefs.LoadBookmarks()
+	if err != nil {
+		println("failed to load bookmarks:", err.Error())
+		bookmarks = remotefs.NewEmptyBookmarks()
+	}
+	a.bookmarks = bookmarks
+
+	a.forwardMgrs = make(map[string]*portforward.Manager)
+
+	savedForwards, err := portforward.LoadForwardStore()
+	if err != nil {
+		println("failed to load saved forwards:", err.Error())
+		savedForwards = portforward.NewEmptyForwardStore()
+	}
+	a.savedForwards = savedForwards
+}
+
+// sessionFor returns the active session for sessionID, or an error if
+// there isn't one.
+func (a *App) sessionFor(sessionID string) (*sshsession.Session, error) {
+	return a.sessions.Get(sessionID)
+}
+
+const connectTimeout = 10 * time.Second
+
+// Connect dials name's profile over SSH, opens an interactive PTY
+// shell sized cols x rows, and registers the resulting session under
+// its profile name so every sessionID-taking method (UploadFiles,
+// StartService, ListProcesses, ...) can reach it. The returned session
+// ID is always name itself, matching the convention the rest of this
+// file already assumes (schedulerRunner.Run, StartLogTail, ...).
+//
+// Connecting to a profile that already has an active session closes
+// the old one first, so a stale or dropped connection doesn't leak.
+func (a *App) Connect(name string, cols, rows int) (string, error) {
+	p := a.profiles.Get(name)
+	if p == nil {
+		return "", fmt.Errorf("profile %q not found", name)
+	}
+
+	a.mu.Lock()
+	v := a.vault
+	a.mu.Unlock()
+
+	auth, err := profile.AuthMethod(*p, v)
+	if err != nil {
+		return "", fmt.Errorf("connect to %q: %w", name, err)
+	}
+
+	config := &ssh.ClientConfig{
+		User:            p.Username,
+		Auth:            []ssh.AuthMethod{auth},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         connectTimeout,
+	}
+
+	client, err := ssh.Dial("tcp", fmt.Sprintf("%s:%d", p.Host, p.Port), config)
+	if err != nil {
+		return "", fmt.Errorf("connect to %q: %w", name, err)
+	}
+
+	sess, err := sshsession.Open(name, p.Host, client, p.PTYOptions(), cols, rows)
+	if err != nil {
+		return "", fmt.Errorf("connect to %q: %w", name, err)
+	}
+	sess.SetShellOptions(p.ShellOptions())
+
+	sess.OnOutput(func(e sshsession.OutputEvent) {
+		runtime.EventsEmit(a.ctx, "session:output", e)
+	})
+	sess.OnSudoPrompt(func(e sshsession.SudoPromptEvent) {
+		runtime.EventsEmit(a.ctx, "session:sudoPrompt", e)
+	})
+
+	if old, err := a.sessions.Get(name); err == nil {
+		old.Close()
+	}
+	a.sessions.Add(sess)
+
+	if err := a.RecordProfileConnect(name); err != nil {
+		println("failed to record profile connect:", err.Error())
+	}
+	if err := a.RestoreSavedPortForwards(sess.ID); err != nil {
+		println("failed to restore saved port forwards:", err.Error())
+	}
+	return sess.ID, nil
+}
+
+// Disconnect closes sessionID's session and deregisters it.
+func (a *App) Disconnect(sessionID string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	a.sessions.Remove(sessionID)
+	return sess.Close()
+}
+
+func (a *App) shutdown(ctx context.Context) {
+	a.configWatcher.Stop()
+}
+
+// SaveOutputAsNote saves the given captured output as a markdown note for
+// host, attaching command/time frontmatter.
+func (a *App) SaveOutputAsNote(host, command, output string) (string, error) {
+	return sendto.SaveAsNote(sendto.Result{
+		Host:    host,
+		Command: command,
+		Output:  output,
+		When:    time.Now(),
+	})
+}
+
+// ShareOutputAsPaste exports the given captured output to the configured
+// paste/gist service and returns a shareable link.
+func (a *App) ShareOutputAsPaste(host, command, output string) (string, error) {
+	return sendto.ExportToPaste(sendto.PasteConfig{
+		Endpoint: a.pasteEndpoint,
+		APIToken: a.pasteAPIToken,
+	}, sendto.Result{
+		Host:    host,
+		Command: command,
+		Output:  output,
+		When:    time.Now(),
+	})
+}
+
+// GetHostNotes returns the saved documentation notes for host.
+func (a *App) GetHostNotes(host string) (string, error) {
+	return notes.GetHostNotes(host)
+}
+
+// SaveHostNotes saves content as host's documentation notes, keeping the
+// previous version in its revision history.
+func (a *App) SaveHostNotes(host, content string) error {
+	return notes.SaveHostNotes(host, content)
+}
+
+// ListHostNoteRevisions returns the revision history for host's notes.
+func (a *App) ListHostNoteRevisions(host string) ([]notes.Revision, error) {
+	return notes.ListRevisions(host)
+}
+
+// StartHealthPolling begins periodic background refreshes of the
+// profile health dashboard.
+func (a *App) StartHealthPolling() {
+	a.healthPoller.Start()
+}
+
+// StopHealthPolling halts background health refreshes.
+func (a *App) StopHealthPolling() {
+	a.healthPoller.Stop()
+}
+
+// ThrottleHealthPolling slows background health polling by factor (e.g.
+// 2 to halve the rate), for use when the app is backgrounded or busy.
+func (a *App) ThrottleHealthPolling(factor float64) {
+	a.healthPoller.Throttle(factor)
+}
+
+// StartMetricsPolling begins periodic background refreshes of the
+// per-session system metrics dashboard (CPU, memory, disk, load
+// average), for every session Connect has registered in a.sessions.
+func (a *App) StartMetricsPolling() {
+	a.metricsPoller.Start()
+}
+
+// StopMetricsPolling halts background metrics refreshes.
+func (a *App) StopMetricsPolling() {
+	a.metricsPoller.Stop()
+}
+
+// ThrottleMetricsPolling slows background metrics polling by factor
+// (e.g. 2 to halve the rate), for use when the app is backgrounded or
+// busy.
+func (a *App) ThrottleMetricsPolling(factor float64) {
+	a.metricsPoller.Throttle(factor)
+}
+
+// SaveCommand creates or updates a saved command snippet, optionally
+// filed under a folder and/or tags.
+func (a *App) SaveCommand(c *savedcmd.Command) error {
+	return a.savedCmds.PutWithHistory(c)
+}
+
+// GetCommandEditHistory returns the previous versions recorded for a
+// saved command.
+func (a *App) GetCommandEditHistory(commandID string) ([]*savedcmd.Command, error) {
+	return a.savedCmds.EditHistory(commandID)
+}
+
+// RollbackCommand restores a saved command to a previous version from
+// its edit history.
+func (a *App) RollbackCommand(toVersion *savedcmd.Command) error {
+	return a.savedCmds.Rollback(toVersion.ID, toVersion)
+}
+
+// DeleteCommand removes a saved command by ID.
+func (a *App) DeleteCommand(id string) error {
+	return a.savedCmds.Delete(id)
+}
+
+// ListCommands returns every saved command.
+func (a *App) ListCommands() []*savedcmd.Command {
+	return a.savedCmds.List()
+}
+
+// ListCommandsByFolder returns the saved commands filed under folder.
+func (a *App) ListCommandsByFolder(folder string) []*savedcmd.Command {
+	return a.savedCmds.ListFolder(folder)
+}
+
+// ListCommandsByTag returns the saved commands carrying tag.
+func (a *App) ListCommandsByTag(tag string) []*savedcmd.Command {
+	return a.savedCmds.ListTag(tag)
+}
+
+// SearchCommands fuzzy-searches saved command snippets by name and
+// command text.
+func (a *App) SearchCommands(query string) []fuzzy.Result {
+	var candidates []string
+	for _, c := range a.savedCmds.List() {
+		candidates = append(candidates, c.Name+" "+c.Command)
+	}
+	return fuzzy.Search(query, candidates)
+}
+
+// GetTimedHistory returns profileName's history with when each command
+// ran and how long it took.
+func (a *App) GetTimedHistory(profileName string) ([]history.Entry, error) {
+	return history.LoadTimed(profileName)
+}
+
+// ExportHistorySync bundles every profile's history, encrypts it with
+// passphrase, and returns it base64-encoded so it can be carried to
+// another machine (e.g. committed via SyncConfigsWithGit) and merged in
+// with ImportHistorySync.
+func (a *App) ExportHistorySync(passphrase string) (string, error) {
+	data, err := history.ExportEncrypted(passphrase)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// ImportHistorySync decrypts a base64-encoded blob produced by
+// ExportHistorySync and merges its history into the local history files,
+// keeping existing entries and only appending commands not already
+// present.
+func (a *App) ImportHistorySync(encoded, passphrase string) error {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("decode history sync blob: %w", err)
+	}
+	return history.ImportEncrypted(data, passphrase)
+}
+
+// AddCommandToHistory records command in profileName's history, first
+// masking any sensitive data (passwords, tokens) matched by the
+// configured redaction rules.
+func (a *App) AddCommandToHistory(profileName, command string) error {
+	a.mu.Lock()
+	rules := a.redactionRules
+	a.mu.Unlock()
+
+	command, _ = redact.Apply(command, rules)
+	return history.Append(profileName, command)
+}
+
+// SetRedactionRuleNames restricts history redaction to the named rules
+// from redact.DefaultRules, or restores every default rule when names
+// is empty.
+func (a *App) SetRedactionRuleNames(names []string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(names) == 0 {
+		a.redactionRules = redact.DefaultRules
+		return
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, n := range names {
+		wanted[n] = true
+	}
+
+	var rules []redact.Rule
+	for _, r := range redact.DefaultRules {
+		if wanted[r.Name] {
+			rules = append(rules, r)
+		}
+	}
+	a.redactionRules = rules
+}
+
+// SetHistoryMaxLines configures how many lines a profile's history file
+// may grow to before RotateHistory archives the overflow. A value of 0
+// resets it to history.MaxLines.
+func (a *App) SetHistoryMaxLines(maxLines int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.historyMaxLines = maxLines
+}
+
+// RotateHistory archives profileName's oldest history lines once its
+// history file exceeds the configured max size.
+func (a *App) RotateHistory(profileName string) error {
+	a.mu.Lock()
+	maxLines := a.historyMaxLines
+	a.mu.Unlock()
+	return history.Rotate(profileName, maxLines, a.historyPins)
+}
+
+// PinHistoryEntry marks command as pinned for profileName, so it's never
+// archived by RotateHistory and is returned first by GetCommandHistory.
+func (a *App) PinHistoryEntry(profileName, command string) error {
+	return a.historyPins.Pin(profileName, command)
+}
+
+// UnpinHistoryEntry removes command from profileName's pinned entries.
+func (a *App) UnpinHistoryEntry(profileName, command string) error {
+	return a.historyPins.Unpin(profileName, command)
+}
+
+// GetCommandHistory returns profileName's command history with pinned
+// entries first, followed by the rest in their usual order.
+func (a *App) GetCommandHistory(profileName string) ([]string, error) {
+	commands, err := history.Load(profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	pinned := a.historyPins.List(profileName)
+	pinnedSet := make(map[string]bool, len(pinned))
+	for _, c := range pinned {
+		pinnedSet[c] = true
+	}
+
+	rest := make([]string, 0, len(commands))
+	for _, c := range commands {
+		if !pinnedSet[c] {
+			rest = append(rest, c)
+		}
+	}
+	return append(pinned, rest...), nil
+}
+
+// ExportHistory serializes profileName's command history to a portable
+// JSON blob.
+func (a *App) ExportHistory(profileName string) (string, error) {
+	data, err := history.Export(profileName)
+	return string(data), err
+}
+
+// ImportHistory merges the commands encoded in data into profileName's
+// history, returning how many were imported.
+func (a *App) ImportHistory(profileName, data string) (int, error) {
+	return history.Import(profileName, []byte(data))
+}
+
+// SearchHistoryAcrossProfiles searches every profile's command history
+// for query, regardless of which profile is currently connected.
+func (a *App) SearchHistoryAcrossProfiles(query string) ([]history.Hit, error) {
+	return history.SearchAll(query)
+}
+
+// GetRankedHistory returns profileName's history deduplicated and
+// ranked by how often each command was run.
+func (a *App) GetRankedHistory(profileName string) ([]history.Ranked, error) {
+	commands, err := history.Load(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return history.Dedup(commands), nil
+}
+
+// GetSuggestions returns ranked autocomplete completions for prefix,
+// merging sessionID's command history, saved commands, and the remote
+// shell's PATH executables (collected lazily and cached per host).
+func (a *App) GetSuggestions(sessionID, prefix string) ([]fuzzy.Result, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	commands, err := history.Load(sess.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	var saved []string
+	for _, c := range a.savedCmds.List() {
+		saved = append(saved, c.Command)
+	}
+
+	execs, err := a.suggestions.Executables(sess.Host, sess)
+	if err != nil {
+		execs = nil
+	}
+
+	return suggest.Merge(prefix, commands, saved, execs), nil
+}
+
+// SearchHistory fuzzy-searches profileName's command history.
+func (a *App) SearchHistory(profileName, query string) ([]fuzzy.Result, error) {
+	commands, err := history.Load(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return fuzzy.Search(query, commands), nil
+}
+
+// RecordHistorySQLite appends a command to the SQLite-backed history
+// store, for installs that want indexed search over a large history.
+func (a *App) RecordHistorySQLite(profileName, command string) error {
+	if a.sqliteHistory == nil {
+		return fmt.Errorf("sqlite history store not available")
+	}
+	return a.sqliteHistory.Append(profileName, command)
+}
+
+// LoadHistorySQLite returns profileName's history from the SQLite
+// backend.
+func (a *App) LoadHistorySQLite(profileName string) ([]string, error) {
+	if a.sqliteHistory == nil {
+		return nil, fmt.Errorf("sqlite history store not available")
+	}
+	return a.sqliteHistory.Load(profileName)
+}
+
+// ScanPortRange probes a range of ports on host and returns the open
+// ones along with whatever service banner they offer.
+func (a *App) ScanPortRange(host string, start, end int) []portscan.Result {
+	return portscan.ProbeRange(host, start, end)
+}
+
+// SuggestPortForwards scans captured output for addresses that look
+// like a service just started listening, so the command palette can
+// offer a one-click "forward this" action.
+func (a *App) SuggestPortForwards(output string) []portforward.Suggestion {
+	return portforward.DetectForwardable(output)
+}
+
+// forwardManagerFor returns sessionID's port forward manager, lazily
+// creating one the first time a forward is requested on that session.
+func (a *App) forwardManagerFor(sessionID string) (*portforward.Manager, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	mgr, ok := a.forwardMgrs[sessionID]
+	if !ok {
+		mgr = portforward.New(sess.UnderlyingClient(), func(e portforward.HealthEvent) {
+			runtime.EventsEmit(a.ctx, "forward_health", e)
+		})
+		a.forwardMgrs[sessionID] = mgr
+	}
+	return mgr, nil
+}
+
+// PortForward opens a local listener on localPort that forwards every
+// connection to remoteHost:remotePort through sessionID's SSH
+// connection, returning an ID to manage it by and the local port that
+// ended up listening. remoteHost is resolved from the remote side, so
+// it can be any host the remote can reach (e.g. "internal-db") and not
+// just one on the remote machine itself; an empty remoteHost defaults
+// to "localhost". A localPort of 0 asks the OS to pick a free port
+// instead of failing with "address already in use" when the preferred
+// one is taken; the port actually chosen is returned. name and
+// description are optional labels shown in the UI; every other API
+// addresses the forward by its stable ID rather than by port numbers.
+func (a *App) PortForward(sessionID string, localPort int, remoteHost string, remotePort int, name, description string) (id string, chosenPort int, err error) {
+	if remoteHost == "" {
+		// Most forwards tunnel to a service on the remote host itself,
+		// so default rather than forcing every caller to pass
+		// "localhost" explicitly.
+		remoteHost = "localhost"
+	}
+
+	mgr, err := a.forwardManagerFor(sessionID)
+	if err != nil {
+		return "", 0, err
+	}
+
+	a.mu.Lock()
+	a.forwardNextID++
+	id = fmt.Sprintf("fwd-%d", a.forwardNextID)
+	a.mu.Unlock()
+
+	f := &portforward.Forward{
+		ID:          id,
+		Name:        name,
+		Description: description,
+		Profile:     sessionID,
+		LocalPort:   localPort,
+		RemoteHost:  remoteHost,
+		RemotePort:  remotePort,
+	}
+	if err := mgr.Start(f); err != nil {
+		return "", 0, err
+	}
+	return f.ID, f.LocalPort, nil
+}
+
+// PreviewRemoteWebApp forwards remoteHost:remotePort through sessionID's
+// SSH connection exactly like PortForward, then waits for it to answer
+// HTTP requests and opens the local URL in the user's default browser —
+// a one-click "preview what's running on the server" flow. A localPort
+// of 0 asks the OS to pick a free port.
+func (a *App) PreviewRemoteWebApp(sessionID string, localPort int, remoteHost string, remotePort int, name, description string) (id string, chosenPort int, err error) {
+	if remoteHost == "" {
+		remoteHost = "localhost"
+	}
+
+	mgr, err := a.forwardManagerFor(sessionID)
+	if err != nil {
+		return "", 0, err
+	}
+
+	a.mu.Lock()
+	a.forwardNextID++
+	id = fmt.Sprintf("fwd-%d", a.forwardNextID)
+	a.mu.Unlock()
+
+	f := &portforward.Forward{
+		ID:          id,
+		Name:        name,
+		Description: description,
+		Profile:     sessionID,
+		LocalPort:   localPort,
+		RemoteHost:  remoteHost,
+		RemotePort:  remotePort,
+	}
+	if err := mgr.ForwardAndOpen(f); err != nil {
+		return "", 0, err
+	}
+	return f.ID, f.LocalPort, nil
+}
+
+// StopPortForward stops a forward started by PortForward, closing its
+// listener and every connection currently relaying through it, and
+// emits a "forward_stopped" event once it's actually torn down.
+func (a *App) StopPortForward(sessionID, forwardID string) error {
+	a.mu.Lock()
+	mgr, ok := a.forwardMgrs[sessionID]
+	a.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("no port forwards active for session %q", sessionID)
+	}
+
+	if err := mgr.Stop(forwardID); err != nil {
+		return err
+	}
+	runtime.EventsEmit(a.ctx, "forward_stopped", forwardID)
+	return nil
+}
+
+// GetActivePortForwards returns every forward currently active on
+// sessionID's connection, including traffic stats.
+func (a *App) GetActivePortForwards(sessionID string) ([]portforward.Info, error) {
+	a.mu.Lock()
+	mgr, ok := a.forwardMgrs[sessionID]
+	a.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+	return mgr.List(), nil
+}
+
+// SavePortForward persists a forward configuration under sessionID's
+// host, so RestoreSavedPortForwards can re-establish it later without
+// the user recreating it by hand. name and description are optional and
+// carried through to the restored forward.
+func (a *App) SavePortForward(sessionID string, localPort int, remoteHost string, remotePort int, name, description string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return a.savedForwards.Save(sess.Host, portforward.SavedForward{
+		Name:        name,
+		Description: description,
+		LocalPort:   localPort,
+		RemoteHost:  remoteHost,
+		RemotePort:  remotePort,
+	})
+}
+
+// RemoveSavedPortForward deletes sessionID's host's saved forward on
+// localPort, if any.
+func (a *App) RemoveSavedPortForward(sessionID string, localPort int) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return a.savedForwards.Remove(sess.Host, localPort)
+}
+
+// ListSavedPortForwards returns sessionID's host's saved forwards.
+func (a *App) ListSavedPortForwards(sessionID string) ([]portforward.SavedForward, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return a.savedForwards.List(sess.Host), nil
+}
+
+// RestoreSavedPortForwards re-establishes every forward saved for
+// sessionID's host, emitting a "forward_restored" event per attempt
+// (success or failure) so the UI can surface status for each one.
+// Connect calls this itself right after registering a new session, so
+// the user's tunnels come back automatically on every reconnection.
+func (a *App) RestoreSavedPortForwards(sessionID string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range a.savedForwards.List(sess.Host) {
+		result := portforward.RestoreResult{
+			Name:        f.Name,
+			Description: f.Description,
+			LocalPort:   f.LocalPort,
+			RemoteHost:  f.RemoteHost,
+			RemotePort:  f.RemotePort,
+		}
+		if _, _, err := a.PortForward(sessionID, f.LocalPort, f.RemoteHost, f.RemotePort, f.Name, f.Description); err != nil {
+			result.Err = err.Error()
+		}
+		runtime.EventsEmit(a.ctx, "forward_restored", result)
+	}
+	return nil
+}
+
+// GetPortForward returns a snapshot of one active forward by ID, so
+// callers that only kept the ID around (rather than polling
+// GetActivePortForwards) can still look up its current stats.
+func (a *App) GetPortForward(sessionID, forwardID string) (portforward.Info, error) {
+	a.mu.Lock()
+	mgr, ok := a.forwardMgrs[sessionID]
+	a.mu.Unlock()
+	if !ok {
+		return portforward.Info{}, fmt.Errorf("no port forwards active for session %q", sessionID)
+	}
+	return mgr.Get(forwardID)
+}
+
+// CopyToLocalClipboard emits an OSC 52 sequence on a session so the
+// terminal's own clipboard integration picks up text locally.
+func (a *App) CopyToLocalClipboard(sessionID, text string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return sess.WriteLine(clipboard.OSC52Sequence(text))
+}
+
+// CopyToRemoteClipboard copies text into the remote host's clipboard via
+// whichever of pbcopy/xclip/xsel is available there.
+func (a *App) CopyToRemoteClipboard(sessionID, text string) (string, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return "", err
+	}
+	return sess.Run(clipboard.RemoteCopyCommand(text))
+}
+
+// SyncConfigsWithGit commits any changes under ./configs and the saved
+// commands file, then pushes to remote so profiles and snippets stay in
+// sync across machines.
+func (a *App) SyncConfigsWithGit(remote string) error {
+	if err := gitsync.Init("."); err != nil {
+		return err
+	}
+	if err := gitsync.Commit(".", "sync profiles and snippets"); err != nil {
+		return err
+	}
+	return gitsync.Push(".", remote)
+}
+
+// PullConfigsFromGit pulls profile and snippet changes from remote.
+func (a *App) PullConfigsFromGit(remote string) error {
+	return gitsync.Pull(".", remote)
+}
+
+// DiffText computes the line-level differential patch turning oldText
+// into newText. The patch carries every line of newText, kept lines
+// included, so it is not smaller than newText itself.
+func (a *App) DiffText(oldText, newText string) []textsync.Op {
+	return textsync.Diff(oldText, newText)
+}
+
+// ApplyTextDiff reconstructs text from a differential patch produced by
+// DiffText.
+func (a *App) ApplyTextDiff(ops []textsync.Op) string {
+	return textsync.Apply(ops)
+}
+
+// ListDirectory returns the contents of dir on sessionID's host.
+func (a *App) ListDirectory(sessionID, dir string) ([]remotefs.Entry, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return remotefs.ListDirectory(sess, dir)
+}
+
+// ListDirectoryFiltered is like ListDirectory, but hides dotfiles,
+// filters by glob, and sorts server-side.
+func (a *App) ListDirectoryFiltered(sessionID, dir string, hideDotfiles bool, globFilter string, sortBy remotefs.SortBy) ([]remotefs.Entry, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return remotefs.ListDirectoryFiltered(sess, dir, remotefs.ListOptions{
+		HideDotfiles: hideDotfiles,
+		GlobFilter:   globFilter,
+		SortBy:       sortBy,
+	})
+}
+
+// WatchRemotePath watches path on sessionID's host for changes,
+// emitting "remotefs:change" events until StopWatchingRemotePath is
+// called. It returns a watch ID to stop it later.
+func (a *App) WatchRemotePath(sessionID, path string) (string, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return "", err
+	}
+
+	stop := make(chan struct{})
+	watchID := fmt.Sprintf("watch-%s-%s", sessionID, path)
+
+	a.mu.Lock()
+	a.pathWatches[watchID] = stop
+	a.mu.Unlock()
+
+	err = remotefs.WatchRemotePath(sess, path, func(c filewatch.Change) {
+		runtime.EventsEmit(a.ctx, "remotefs:change", c)
+	}, stop)
+	if err != nil {
+		a.mu.Lock()
+		delete(a.pathWatches, watchID)
+		a.mu.Unlock()
+		return "", err
+	}
+	return watchID, nil
+}
+
+// StopWatchingRemotePath stops a watch started by WatchRemotePath.
+func (a *App) StopWatchingRemotePath(watchID string) error {
+	a.mu.Lock()
+	stop, ok := a.pathWatches[watchID]
+	delete(a.pathWatches, watchID)
+	a.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("watch %q not found", watchID)
+	}
+	close(stop)
+	return nil
+}
+
+// StartLogTail tails paths on profile's host (one `tail -F` per path),
+// optionally filtered remotely via a grep-style filter, emitting
+// "logtail:line" events until StopLogTail is called. It returns a tail
+// ID to stop it later.
+func (a *App) StartLogTail(profile string, paths []string, filter string) (string, error) {
+	sess, err := a.sessionFor(profile)
+	if err != nil {
+		return "", err
+	}
+
+	stop := make(chan struct{})
+	tailID := fmt.Sprintf("logtail-%s-%s", profile, strings.Join(paths, ","))
+
+	a.mu.Lock()
+	a.logTails[tailID] = stop
+	a.mu.Unlock()
+
+	err = logtail.Start(sess, profile, paths, filter, func(e logtail.LineEvent) {
+		runtime.EventsEmit(a.ctx, "logtail:line", e)
+	}, stop)
+	if err != nil {
+		a.mu.Lock()
+		delete(a.logTails, tailID)
+		a.mu.Unlock()
+		return "", err
+	}
+	return tailID, nil
+}
+
+// StopLogTail stops a tail started by StartLogTail.
+func (a *App) StopLogTail(tailID string) error {
+	a.mu.Lock()
+	stop, ok := a.logTails[tailID]
+	delete(a.logTails, tailID)
+	a.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("log tail %q not found", tailID)
+	}
+	close(stop)
+	return nil
+}
+
+// AddBookmark bookmarks path for sessionID's host.
+func (a *App) AddBookmark(sessionID, path string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return a.bookmarks.AddBookmark(sess.Host, path)
+}
+
+// RemoveBookmark removes path from sessionID's host's bookmarks.
+func (a *App) RemoveBookmark(sessionID, path string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return a.bookmarks.RemoveBookmark(sess.Host, path)
+}
+
+// ListBookmarks returns sessionID's host's bookmarked paths.
+func (a *App) ListBookmarks(sessionID string) ([]string, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return a.bookmarks.ListBookmarks(sess.Host), nil
+}
+
+// SetLastVisitedDir records dir as sessionID's host's last-visited file
+// browser directory, so the browser can reopen there next time.
+func (a *App) SetLastVisitedDir(sessionID, dir string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return a.bookmarks.SetLastDir(sess.Host, dir)
+}
+
+// GetLastVisitedDir returns sessionID's host's last-visited file browser
+// directory, or "" if none is recorded.
+func (a *App) GetLastVisitedDir(sessionID string) (string, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return "", err
+	}
+	return a.bookmarks.LastDir(sess.Host), nil
+}
+
+// GetDiskUsage returns a size tree for path on sessionID's host, up to
+// depth levels deep, driven by `du`.
+func (a *App) GetDiskUsage(sessionID, path string, depth int) (*remotefs.DiskUsageNode, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return remotefs.GetDiskUsage(sess, path, depth)
+}
+
+// GetDiskFree reports free/used/total space for every mounted
+// filesystem on sessionID's host, driven by `df`.
+func (a *App) GetDiskFree(sessionID string) ([]remotefs.DiskFree, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return remotefs.GetDiskFree(sess)
+}
+
+// PreviewRemoteFile returns a text snippet or base64 image preview of
+// remotePath on sessionID's host, without downloading the whole file.
+func (a *App) PreviewRemoteFile(sessionID, remotePath string, maxBytes int) (remotefs.Preview, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return remotefs.Preview{}, err
+	}
+	return remotefs.PreviewRemoteFile(sess, remotePath, maxBytes)
+}
+
+// ListDirectoryPage returns a sorted, paginated slice of dir's entries
+// on sessionID's host, so huge directories load incrementally instead
+// of freezing the UI on one giant listing.
+func (a *App) ListDirectoryPage(sessionID, dir, token string, limit int, sortBy remotefs.SortBy) (remotefs.Page, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return remotefs.Page{}, err
+	}
+	return remotefs.ListDirectoryPage(sess, dir, token, limit, sortBy)
+}
+
+// SetRemotePermissions chmods path on sessionID's host to mode
+// (interpreted as an octal permission bits value, e.g. 0o755),
+// optionally recursing into a directory's contents.
+func (a *App) SetRemotePermissions(sessionID, path string, mode uint32, recursive bool) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return remotefs.SetPermissions(sess, path, os.FileMode(mode), recursive)
+}
+
+// SetRemoteOwner chowns path on sessionID's host to uid:gid, falling
+// back to `sudo chown` when the SFTP subsystem refuses a direct chown.
+func (a *App) SetRemoteOwner(sessionID, path string, uid, gid int, recursive bool) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return remotefs.SetOwner(sess, path, uid, gid, recursive)
+}
+
+// ReadRemoteLink returns the target a symlink on sessionID's host points
+// to.
+func (a *App) ReadRemoteLink(sessionID, linkPath string) (string, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return "", err
+	}
+	return remotefs.ReadLink(sess, linkPath)
+}
+
+// CreateRemoteSymlink creates a symlink on sessionID's host at linkPath
+// pointing to target.
+func (a *App) CreateRemoteSymlink(sessionID, target, linkPath string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return remotefs.CreateSymlink(sess, target, linkPath)
+}
+
+// OpenRemoteFileForEdit downloads remotePath from sessionID's host to a
+// temp file, opens it in the OS default editor, and re-uploads it on
+// every save, emitting "editsync:status" events. It returns an edit ID
+// to pass to CloseRemoteFileEdit when the user is done.
+func (a *App) OpenRemoteFileForEdit(sessionID, remotePath string) (string, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return "", err
+	}
+
+	stop := make(chan struct{})
+	editID := fmt.Sprintf("edit-%s-%s", sessionID, remotePath)
+
+	a.mu.Lock()
+	a.editSessions[editID] = stop
+	a.mu.Unlock()
+
+	err = editsync.Open(sess, remotePath, func(e editsync.Event) {
+		runtime.EventsEmit(a.ctx, "editsync:status", e)
+		if e.Status == editsync.Synced {
+			if err := audit.Record(audit.Entry{Host: sess.Host, Kind: audit.KindFileEdit, Detail: e.RemotePath, When: time.Now()}); err != nil {
+				println("failed to record file edit audit entry:", err.Error())
+			}
+		}
+	}, stop)
+	if err != nil {
+		a.mu.Lock()
+		delete(a.editSessions, editID)
+		a.mu.Unlock()
+		return "", err
+	}
+	return editID, nil
+}
+
+// CloseRemoteFileEdit stops watching and syncing the local copy opened
+// by OpenRemoteFileForEdit.
+func (a *App) CloseRemoteFileEdit(editID string) error {
+	a.mu.Lock()
+	stop, ok := a.editSessions[editID]
+	delete(a.editSessions, editID)
+	a.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("edit session %q not found", editID)
+	}
+	close(stop)
+	return nil
+}
+
+// UploadTree uploads localDir to remoteDir on sessionID's host,
+// streaming the whole tree as one tar.gz when the remote shell has tar
+// (much faster than one SFTP round-trip per file for trees with many
+// small files), falling back to per-file SFTP otherwise.
+func (a *App) UploadTree(sessionID, localDir, remoteDir string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	if err := remotefs.UploadTree(sess, localDir, remoteDir); err != nil {
+		return err
+	}
+
+	if err := audit.Record(audit.Entry{Host: sess.Host, Kind: audit.KindUpload, Detail: remoteDir, When: time.Now()}); err != nil {
+		println("failed to record upload audit entry:", err.Error())
+	}
+	return nil
+}
+
+// DownloadTree downloads remoteDir from sessionID's host into localDir,
+// symmetric to UploadTree.
+func (a *App) DownloadTree(sessionID, remoteDir, localDir string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return remotefs.DownloadTree(sess, remoteDir, localDir)
+}
+
+// CompressRemote archives paths into archivePath on sessionID's host.
+func (a *App) CompressRemote(sessionID string, paths []string, archivePath string, format remotefs.ArchiveFormat) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return remotefs.CompressRemote(sess, paths, archivePath, format)
+}
+
+// ExtractRemote extracts archivePath into destDir on sessionID's host.
+func (a *App) ExtractRemote(sessionID, archivePath, destDir string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return remotefs.ExtractRemote(sess, archivePath, destDir)
+}
+
+// CopyRemote copies src to dst entirely on sessionID's host, without
+// round-tripping the data through the local machine.
+func (a *App) CopyRemote(sessionID, src, dst string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return remotefs.CopyRemote(sess, src, dst)
+}
+
+// MoveRemote moves src to dst entirely on sessionID's host.
+func (a *App) MoveRemote(sessionID, src, dst string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return remotefs.MoveRemote(sess, src, dst)
+}
+
+// QueueLocalTransfer queues a copy between two local paths and returns a
+// transfer ID for tracking via ListTransfers and the transfer:progress
+// event, through the same manager that UploadFiles and
+// TransferBetweenHosts enqueue their SFTP-backed transfers on.
+func (a *App) QueueLocalTransfer(direction transfer.Direction, srcPath, dstPath string, policy transfer.ConflictPolicy) string {
+	return a.transfers.EnqueueWithPolicy(direction, transfer.LocalFile{Path: srcPath}, transfer.LocalFile{Path: dstPath}, policy)
+}
+
+// DeleteRemoteFile removes remotePath on sessionID's host. When
+// useTrash is set, it's moved into ~/.terminalapp_trash instead of being
+// unlinked, recoverable via RestoreFromTrash.
+func (a *App) DeleteRemoteFile(sessionID, remotePath string, useTrash bool) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return remotefs.DeleteRemoteFile(sess, remotePath, useTrash)
+}
+
+// ListRemoteTrash returns every item currently in sessionID's host's
+// remote trash.
+func (a *App) ListRemoteTrash(sessionID string) ([]remotefs.TrashedItem, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return remotefs.ListTrash(sess)
+}
+
+// RestoreFromTrash moves a trashed item back to its original path.
+func (a *App) RestoreFromTrash(sessionID, id string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return remotefs.RestoreFromTrash(sess, id)
+}
+
+// PurgeTrash permanently deletes a trashed item, or every item when id
+// is empty.
+func (a *App) PurgeTrash(sessionID, id string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return remotefs.PurgeTrash(sess, id)
+}
+
+// BulkFileOperation applies op to every path in paths on sessionID's
+// host with a bounded worker pool, emitting "bulk:progress" events with
+// a consolidated snapshot of every item's result so far.
+func (a *App) BulkFileOperation(sessionID string, op remotefs.BulkOp, paths []string, opts remotefs.BulkOptions) ([]remotefs.BulkItemResult, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return remotefs.BulkFileOperation(sess, op, paths, opts, func(p remotefs.BulkProgress) {
+		runtime.EventsEmit(a.ctx, "bulk:progress", p)
+	}), nil
+}
+
+// TransferBetweenHosts queues a copy of srcPath on srcSessionID's host
+// to dstPath on dstSessionID's host, streaming data through this app
+// (two SFTP connections, relayed) so users can move files between
+// servers without a local intermediate copy.
+func (a *App) TransferBetweenHosts(srcSessionID, srcPath, dstSessionID, dstPath string, policy transfer.ConflictPolicy) (string, error) {
+	srcSess, err := a.sessionFor(srcSessionID)
+	if err != nil {
+		return "", err
+	}
+	dstSess, err := a.sessionFor(dstSessionID)
+	if err != nil {
+		return "", err
+	}
+
+	src := transfer.RemoteFile{Sess: srcSess, Path: srcPath}
+	dst := transfer.RemoteFile{Sess: dstSess, Path: dstPath}
+	id := a.transfers.EnqueueWithPolicy(transfer.Relay, src, dst, policy)
+
+	a.mu.Lock()
+	a.transferAudit[id] = transferAuditMeta{host: dstSess.Host, path: dstPath}
+	a.mu.Unlock()
+	return id, nil
+}
+
+// UploadFiles queues a batch upload of localPaths to remoteDir on
+// sessionID's host, preserving each path's position relative to its
+// nearest common ancestor so dropped folders keep their structure. It
+// returns one transfer ID per file for per-file progress; ListTransfers
+// reports each one's state alongside the rest of the queue.
+func (a *App) UploadFiles(sessionID string, localPaths []string, remoteDir string, policy transfer.ConflictPolicy) ([]string, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	base := commonDir(localPaths)
+
+	ids := make([]string, 0, len(localPaths))
+	for _, localPath := range localPaths {
+		rel, err := filepath.Rel(base, localPath)
+		if err != nil {
+			rel = filepath.Base(localPath)
+		}
+		remotePath := path.Join(remoteDir, filepath.ToSlash(rel))
+
+		id := a.transfers.EnqueueWithPolicy(transfer.Upload, transfer.LocalFile{Path: localPath}, transfer.RemoteFile{Sess: sess, Path: remotePath}, policy)
+		ids = append(ids, id)
+
+		a.mu.Lock()
+		a.transferAudit[id] = transferAuditMeta{host: sess.Host, path: remotePath}
+		a.mu.Unlock()
+	}
+	return ids, nil
+}
+
+// commonDir returns the deepest directory that contains every path in
+// paths, so a batch upload of a dropped folder can preserve the
+// folder's internal structure under the remote destination.
+func commonDir(paths []string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+
+	common := filepath.Dir(paths[0])
+	for _, p := range paths[1:] {
+		dir := filepath.Dir(p)
+		for common != "." && common != string(filepath.Separator) &&
+			!strings.HasPrefix(dir, common+string(filepath.Separator)) && dir != common {
+			common = filepath.Dir(common)
+		}
+	}
+	return common
+}
+
+// QueueCompressedTransfer queues a copy between two local paths like
+// QueueLocalTransfer, but gzips the data in flight to cut transfer time
+// on slow links.
+func (a *App) QueueCompressedTransfer(direction transfer.Direction, srcPath, dstPath string) string {
+	src := transfer.CompressedSource{Source: transfer.LocalFile{Path: srcPath}}
+	dst := transfer.CompressedSink{Sink: transfer.LocalFile{Path: dstPath}}
+	return a.transfers.Enqueue(direction, src, dst)
+}
+
+// CompareChecksums computes the SHA-256 digest of localPath and of
+// remotePath on sessionID's host, reporting whether they match.
+func (a *App) CompareChecksums(sessionID, localPath, remotePath string) (bool, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return false, err
+	}
+	if err := checksum.Verify(sess, localPath, remotePath); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// PauseTransfer pauses a running transfer; it resumes from where it left
+// off when ResumeTransfer is called.
+func (a *App) PauseTransfer(id string) error {
+	return a.transfers.PauseTransfer(id)
+}
+
+// ResumeTransfer resumes a paused transfer.
+func (a *App) ResumeTransfer(id string) error {
+	return a.transfers.ResumeTransfer(id)
+}
+
+// CancelTransfer stops a queued, running, or paused transfer.
+func (a *App) CancelTransfer(id string) error {
+	return a.transfers.CancelTransfer(id)
+}
+
+// ResumeFailedTransfer retries a failed or cancelled transfer, picking
+// up from the bytes already moved when both ends support it instead of
+// starting over from scratch.
+func (a *App) ResumeFailedTransfer(id string) error {
+	return a.transfers.ResumeFailedTransfer(id)
+}
+
+// ListTransfers returns the current progress of every transfer the
+// queue knows about.
+func (a *App) ListTransfers() []transfer.Progress {
+	return a.transfers.ListTransfers()
+}
+
+// RunSavedCommand renders and runs a saved command on a session,
+// recording its execution statistics.
+func (a *App) RunSavedCommand(sessionID, commandID string, vars map[string]string) (string, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return "", err
+	}
+	command, err := a.RenderCommand(commandID, vars)
+	if err != nil {
+		return "", err
+	}
+
+	start := time.Now()
+	output, runErr := sess.Run(command)
+	elapsed := time.Since(start)
+
+	a.cmdStats.Record(commandID, elapsed, runErr != nil)
+	history.AppendTimed(sess.Host, history.Entry{Command: command, RanAt: start, Elapsed: elapsed})
+	a.RotateHistory(sess.Host)
+
+	return output, runErr
+}
+
+// GetCommandStats returns the execution statistics recorded for a saved
+// command.
+func (a *App) GetCommandStats(commandID string) savedcmd.Stats {
+	return a.cmdStats.Get(commandID)
+}
+
+// RunCommandChain executes a saved command chain against a session,
+// skipping steps whose condition is not satisfied and emitting
+// commandchain:step events as each executed step completes.
+func (a *App) RunCommandChain(sessionID string, chain savedcmd.Chain) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+
+	return savedcmd.RunChain(a.savedCmds, chain, sess, func(step savedcmd.ChainStep, result savedcmd.StepResult) {
+		runtime.EventsEmit(a.ctx, "commandchain:step", sessionID, step, result.Output, fmt.Sprint(result.Err))
+	})
+}
+
+// GetCommandPromptVariables returns the variable names a saved command
+// needs filled in before it can run.
+func (a *App) GetCommandPromptVariables(id string) ([]string, error) {
+	c, err := a.savedCmds.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return c.PromptVariables(), nil
+}
+
+// RenderCommand expands a saved command's template variables with the
+// given values, returning the concrete command to run.
+func (a *App) RenderCommand(id string, vars map[string]string) (string, error) {
+	c, err := a.savedCmds.Get(id)
+	if err != nil {
+		return "", err
+	}
+	return c.Render(vars)
+}
+
+// ListCommandsForProfile returns the saved commands usable from
+// profileName: commands scoped to that profile plus every global one.
+func (a *App) ListCommandsForProfile(profileName string) []*savedcmd.Command {
+	return a.savedCmds.ListForProfile(profileName)
+}
+
+// ListGlobalCommands returns the saved commands available from every
+// profile.
+func (a *App) ListGlobalCommands() []*savedcmd.Command {
+	return a.savedCmds.ListGlobal()
+}
+
+// SetLocale sets the locale and time zone used when formatting
+// timestamps and sizes for display.
+func (a *App) SetLocale(locale, timeZone string) {
+	a.mu.Lock()
+	a.formatOptions = format.Options{Locale: locale, TimeZone: timeZone}
+	a.mu.Unlock()
+}
+
+// FormatTimestamp renders t using the configured locale and time zone.
+func (a *App) FormatTimestamp(t time.Time) string {
+	a.mu.Lock()
+	opts := a.formatOptions
+	a.mu.Unlock()
+	return format.Timestamp(t, opts)
+}
+
+// FormatSize renders a byte count as a human-readable, locale-aware
+// string.
+func (a *App) FormatSize(bytes int64) string {
+	a.mu.Lock()
+	opts := a.formatOptions
+	a.mu.Unlock()
+	return format.Size(bytes, opts)
+}
+
+// SetReleaseChannel switches which release channel update checks use.
+func (a *App) SetReleaseChannel(channel selfupdate.Channel) {
+	a.mu.Lock()
+	a.releaseChannel = channel
+	a.mu.Unlock()
+}
+
+// CheckForUpdates checks the configured release channel for a newer
+// build than the one currently running.
+func (a *App) CheckForUpdates() (*selfupdate.Release, bool, error) {
+	a.mu.Lock()
+	channel := a.releaseChannel
+	a.mu.Unlock()
+
+	return selfupdate.CheckForUpdates(channel, a.version)
+}
+
+// ApplyUpdate downloads rel, verifies it, and replaces the running
+// executable with it. The app must be restarted for it to take effect.
+func (a *App) ApplyUpdate(rel *selfupdate.Release) error {
+	return selfupdate.ApplyUpdate(rel)
+}
+
+// ExportCommands serializes every saved command to a portable JSON blob.
+func (a *App) ExportCommands() (string, error) {
+	data, err := a.savedCmds.Export()
+	return string(data), err
+}
+
+// ImportCommands merges the commands encoded in data into the saved
+// command store, returning how many were imported.
+func (a *App) ImportCommands(data string) (int, error) {
+	return a.savedCmds.Import([]byte(data))
+}
+
+// SaveSessionTemplate creates or updates a "debug bundle" session
+// layout.
+func (a *App) SaveSessionTemplate(t *layout.Template) error {
+	return a.layouts.Put(t)
+}
+
+// ListSessionTemplates returns every saved session template.
+func (a *App) ListSessionTemplates() []*layout.Template {
+	return a.layouts.List()
+}
+
+// GetSessionTemplate returns a saved session template by ID, so the
+// frontend can open every pane it describes in one click.
+func (a *App) GetSessionTemplate(id string) (*layout.Template, error) {
+	return a.layouts.Get(id)
+}
+
+// SetCommandShortcut binds a keyboard chord to a saved command.
+func (a *App) SetCommandShortcut(commandID, chord string) error {
+	return a.savedCmds.SetShortcut(commandID, chord)
+}
+
+// GetCommandByShortcut returns the saved command bound to a keyboard
+// chord, if any.
+func (a *App) GetCommandByShortcut(chord string) (*savedcmd.Command, error) {
+	return a.savedCmds.ByShortcut(chord)
+}
+
+// RenderCommandTemplate expands a saved command template's {{var}}
+// placeholders using the given values.
+func (a *App) RenderCommandTemplate(tmpl string, vars map[string]string) (string, error) {
+	return template.Render(tmpl, vars)
+}
+
+// GetCommandTemplateVariables returns the placeholder names referenced
+// by a saved command template, so the UI can prompt for each one.
+func (a *App) GetCommandTemplateVariables(tmpl string) []string {
+	return template.Variables(tmpl)
+}
+
+// CheckDestructiveCommand returns a warning if command looks
+// destructive, or "" if it looks safe to run without confirmation.
+func (a *App) CheckDestructiveCommand(command string) string {
+	return guard.Check(command)
+}
+
+// EnableSessionTrace turns on low-level SSH debug tracing for a session.
+func (a *App) EnableSessionTrace(sessionID string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	sess.EnableTrace()
+	return nil
+}
+
+// GetSessionTrace returns the trace events recorded for a session.
+func (a *App) GetSessionTrace(sessionID string) ([]sshsession.TraceEvent, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return sess.TraceEvents(), nil
+}
+
+// SwitchSessionUser runs `su - <user>` in a session, supplying password
+// if the sudo prompt fires.
+func (a *App) SwitchSessionUser(sessionID, user, password string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return sess.SwitchUser(user, password)
+}
+
+// DetachSession backgrounds a session so its output stops streaming to
+// the frontend without killing the remote process.
+func (a *App) DetachSession(sessionID string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	sess.Detach()
+	return nil
+}
+
+// ReattachSession resumes streaming output for a detached session and
+// returns the last 4096 bytes of its output; earlier output from while
+// it was detached is not kept.
+func (a *App) ReattachSession(sessionID string) (string, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return "", err
+	}
+	return sess.Reattach()
+}
+
+// GetProfileHealth checks reachability of every saved profile for the
+// health dashboard.
+func (a *App) GetProfileHealth() []health.Status {
+	return health.CheckAll(a.profiles.List())
+}
+
+// CreateProfile validates p and writes it as a new YAML profile,
+// emitting a "profile:changed" event on success.
+func (a *App) CreateProfile(p profile.Profile) error {
+	if err := a.profiles.Create(p); err != nil {
+		return err
+	}
+	runtime.EventsEmit(a.ctx, "profile:changed")
+	return nil
+}
+
+// UpdateProfile validates p and overwrites the profile currently named
+// name (renaming its backing file if p.Name differs), emitting a
+// "profile:changed" event on success.
+func (a *App) UpdateProfile(name string, p profile.Profile) error {
+	if err := a.profiles.Update(name, p); err != nil {
+		return err
+	}
+	runtime.EventsEmit(a.ctx, "profile:changed")
+	return nil
+}
+
+// DeleteProfile removes name's profile and its backing YAML file,
+// emitting a "profile:changed" event on success.
+func (a *App) DeleteProfile(name string) error {
+	if err := a.profiles.Delete(name); err != nil {
+		return err
+	}
+	runtime.EventsEmit(a.ctx, "profile:changed")
+	return nil
+}
+
+// TestProfile validates p and performs a short connect-and-whoami
+// check, letting the UI show exactly what's wrong with a profile before
+// it's saved.
+func (a *App) TestProfile(p profile.Profile) profile.Diagnostics {
+	a.mu.Lock()
+	v := a.vault
+	a.mu.Unlock()
+	return profile.TestProfile(p, v)
+}
+
+// UnlockVault opens the master-password-protected secret vault at
+// startup (or re-opens it after the user re-enters the password),
+// after which profiles with a VaultRef can resolve their password.
+// Returns vault.ErrWrongPassword if masterPassword doesn't decrypt an
+// existing vault file.
+func (a *App) UnlockVault(masterPassword string) error {
+	v, err := vault.Open("./configs/.vault", masterPassword)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	a.vault = v
+	a.mu.Unlock()
+	return nil
+}
+
+// MigrateProfilePasswordToVault moves name's plaintext password out of
+// its YAML file and into the unlocked vault, emitting "profile:changed"
+// on success. The vault must already be unlocked via UnlockVault.
+func (a *App) MigrateProfilePasswordToVault(name string) error {
+	a.mu.Lock()
+	v := a.vault
+	a.mu.Unlock()
+	if v == nil {
+		return fmt.Errorf("vault is locked; call UnlockVault first")
+	}
+
+	if err := a.profiles.MigrateToVault(name, v); err != nil {
+		return err
+	}
+	runtime.EventsEmit(a.ctx, "profile:changed")
+	return nil
+}
+
+// ImportPuTTYSessions converts every session in a PuTTY registry export
+// (regPath) into a profile, creating each one that doesn't already
+// exist by name. It returns the names actually imported plus a warning
+// per session whose key couldn't be converted (e.g. an encrypted .ppk)
+// or whose name collided with an existing profile; it does not fail the
+// whole import for one bad session.
+func (a *App) ImportPuTTYSessions(regPath string) (imported []string, warnings []string, err error) {
+	profiles, warnings, err := profile.ImportPuTTYSessions(regPath, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, p := range profiles {
+		if err := a.profiles.Create(p); err != nil {
+			warnings = append(warnings, fmt.Sprintf("session %q: %v", p.Name, err))
+			continue
+		}
+		imported = append(imported, p.Name)
+	}
+
+	if len(imported) > 0 {
+		runtime.EventsEmit(a.ctx, "profile:changed")
+	}
+	return imported, warnings, nil
+}
+
+// MigrateProfilePasswordToKeychain moves name's plaintext password out
+// of its YAML file and into the OS keychain (Keychain on macOS,
+// libsecret on Linux, Credential Manager on Windows), emitting
+// "profile:changed" on success.
+func (a *App) MigrateProfilePasswordToKeychain(name string) error {
+	if err := a.profiles.MigrateToKeychain(name); err != nil {
+		return err
+	}
+	runtime.EventsEmit(a.ctx, "profile:changed")
+	return nil
+}
+
+// GetCloudConfig returns the saved GCP/Azure project and resource group
+// to query, if configured.
+func (a *App) GetCloudConfig() (cloud.Config, error) {
+	return cloud.LoadConfig()
+}
+
+// SetCloudConfig saves which GCP project and/or Azure resource group
+// ListGCPInstances/ListAzureInstances should query.
+func (a *App) SetCloudConfig(c cloud.Config) error {
+	return cloud.SaveConfig(c)
+}
+
+// ListGCPInstances discovers Compute Engine VMs in the configured GCP
+// project via the gcloud CLI.
+func (a *App) ListGCPInstances() ([]cloud.VM, error) {
+	cfg, err := cloud.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return cloud.GCPBackend{Project: cfg.GCPProject}.ListVMs()
+}
+
+// ListAzureInstances discovers VMs in the configured Azure resource
+// group via the az CLI.
+func (a *App) ListAzureInstances() ([]cloud.VM, error) {
+	cfg, err := cloud.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return cloud.AzureBackend{ResourceGroup: cfg.AzureResourceGroup}.ListVMs()
+}
+
+// ImportCloudVMs turns discovered VMs into profiles using a shared
+// username/sshKeyPath (cloud inventories don't know per-instance login
+// details), creating each one that doesn't already exist by name.
+func (a *App) ImportCloudVMs(vms []cloud.VM, username, sshKeyPath string) (imported []string, warnings []string, err error) {
+	for _, vm := range vms {
+		p := profile.FromCloudVM(vm, username, sshKeyPath)
+		if err := a.profiles.Create(p); err != nil {
+			warnings = append(warnings, fmt.Sprintf("VM %q: %v", vm.Name, err))
+			continue
+		}
+		imported = append(imported, p.Name)
+	}
+
+	if len(imported) > 0 {
+		runtime.EventsEmit(a.ctx, "profile:changed")
+	}
+	return imported, warnings, nil
+}
+
+// ResolveHostTemplate checks typedHost against every wildcard-pattern
+// profile (e.g. "*.internal.corp") and, if one matches, returns a
+// concrete profile for typedHost with that template's user/key/settings
+// applied — so quick-connect can use the right credentials for a host
+// the user just typed without a profile already existing for it.
+func (a *App) ResolveHostTemplate(typedHost string) (profile.Profile, bool) {
+	return a.profiles.MatchTemplate(typedHost)
+}
+
+// RecordProfileConnect bumps name's last-connected time and connect
+// count, for the "recent connections" quick-connect list. Connect
+// calls this itself; it's exported separately for callers (like
+// restoring a detached session) that reconnect without going through
+// Connect.
+func (a *App) RecordProfileConnect(name string) error {
+	return a.activity.RecordConnect(name)
+}
+
+// SetFavorite pins or unpins name for the "recent & pinned" list.
+func (a *App) SetFavorite(name string, favorite bool) error {
+	return a.activity.SetFavorite(name, favorite)
+}
+
+// GetRecentProfiles returns up to limit profiles most recently
+// connected to, most recent first, skipping any name that's since been
+// deleted.
+func (a *App) GetRecentProfiles(limit int) []*profile.Profile {
+	var out []*profile.Profile
+	for _, name := range a.activity.RecentNames(limit) {
+		if p := a.profiles.Get(name); p != nil {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// GetFavoriteProfiles returns every profile pinned as a favorite.
+func (a *App) GetFavoriteProfiles() []*profile.Profile {
+	var out []*profile.Profile
+	for _, name := range a.activity.FavoriteNames() {
+		if p := a.profiles.Get(name); p != nil {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// DuplicateProfile clones name's profile as newName, applying any
+// non-zero field in overrides (e.g. a different Host for the same
+// bastion user/key) atomically. Emits "profile:changed" on success.
+func (a *App) DuplicateProfile(name, newName string, overrides profile.Profile) error {
+	if err := a.profiles.Duplicate(name, newName, overrides); err != nil {
+		return err
+	}
+	runtime.EventsEmit(a.ctx, "profile:changed")
+	return nil
+}
+
+// ExportProfiles bundles every profile, saved command, and the cloud
+// config into a single zip archive (base64-encoded for the frontend to
+// write to disk). See bundle.ExportOptions for secret handling.
+func (a *App) ExportProfiles(includeSecrets bool, encryptPassword string) (string, error) {
+	data, err := bundle.Export(a.profiles, a.savedCmds, bundle.ExportOptions{
+		IncludeSecrets:  includeSecrets,
+		EncryptPassword: encryptPassword,
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// ImportProfiles applies a bundle produced by ExportProfiles (as
+// base64), creating every profile and saved command that doesn't
+// already exist, emitting "profile:changed" if any profile landed.
+// decryptPassword is only needed for a bundle exported with a password.
+func (a *App) ImportProfiles(bundleData, decryptPassword string) (bundle.Result, error) {
+	data, err := base64.StdEncoding.DecodeString(bundleData)
+	if err != nil {
+		return bundle.Result{}, fmt.Errorf("decode bundle: %w", err)
+	}
+
+	res, err := bundle.Import(data, decryptPassword, a.profiles, a.savedCmds)
+	if err != nil {
+		return bundle.Result{}, err
+	}
+
+	if len(res.ProfilesImported) > 0 {
+		runtime.EventsEmit(a.ctx, "profile:changed")
+	}
+	return res, nil
+}
+
+// ListProfilesByGroup returns every profile in group, sorted the way
+// the UI last ordered them.
+func (a *App) ListProfilesByGroup(group string) []*profile.Profile {
+	return a.profiles.ListByGroup(group)
+}
+
+// ReorderProfiles persists the drag-to-reorder result: names, in the
+// order the UI wants them displayed.
+func (a *App) ReorderProfiles(names []string) error {
+	if err := a.profiles.Reorder(names); err != nil {
+		return err
+	}
+	runtime.EventsEmit(a.ctx, "profile:changed")
+	return nil
+}
+
+// BulkConnectGroup returns every profile in group, for the UI to pass
+// to Connect one by one; it deliberately doesn't call Connect itself
+// so the UI can stagger the dials and surface per-profile errors as
+// they come in, instead of one failure aborting the whole batch.
+func (a *App) BulkConnectGroup(group string) []*profile.Profile {
+	return a.profiles.ListByGroup(group)
+}
+
+// CaptureOutputToFile appends output to a local file at path.
+func (a *App) CaptureOutputToFile(path, output string) error {
+	return capture.ToFile(path, []byte(output))
+}
+
+// ScheduleCommand schedules command to run against profileName every
+// interval (in seconds) under the caller-chosen jobID.
+func (a *App) ScheduleCommand(jobID, profileName, command string, intervalSeconds int) error {
+	return a.scheduler.Schedule(&scheduler.Job{
+		ID:       jobID,
+		Profile:  profileName,
+		Command:  command,
+		Interval: time.Duration(intervalSeconds) * time.Second,
+	})
+}
+
+// CancelScheduledCommand stops a previously scheduled job.
+func (a *App) CancelScheduledCommand(jobID string) error {
+	return a.scheduler.Cancel(jobID)
+}
+
+// ListScheduledCommands returns the currently scheduled jobs.
+func (a *App) ListScheduledCommands() []*scheduler.Job {
+	return a.scheduler.List()
+}
+
+// GetHostChangeJournal returns the per-day change journal derived from
+// the audit log for host.
+func (a *App) GetHostChangeJournal(host string) ([]journal.DaySummary, error) {
+	return journal.ForHost(host)
+}
+
+// ExportHostChangeJournal renders host's change journal as plain text.
+func (a *App) ExportHostChangeJournal(host string) (string, error) {
+	summaries, err := journal.ForHost(host)
+	if err != nil {
+		return "", err
+	}
+	return journal.Export(summaries), nil
+}
+
+// RunPipeline runs commands in order on the session identified by
+// sessionID, emitting PipelineProgress events to the frontend as each
+// step completes.
+func (a *App) RunPipeline(sessionID string, steps []pipeline.Step) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+
+	return pipeline.Run(sess, steps, func(p pipeline.Progress) {
+		runtime.EventsEmit(a.ctx, "pipeline:progress", sessionID, p)
+	})
+}
+
+// ListProcesses returns every process currently running on the host
+// behind sessionID, for a task-manager view next to the terminal.
+func (a *App) ListProcesses(sessionID string) ([]procman.Process, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return procman.List(sess)
+}
+
+// ListProcessesSortedByCPU is ListProcesses with the result sorted by
+// CPU usage, highest first.
+func (a *App) ListProcessesSortedByCPU(sessionID string) ([]procman.Process, error) {
+	procs, err := a.ListProcesses(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	procman.SortByCPU(procs)
+	return procs, nil
+}
+
+// ListProcessesSortedByMemory is ListProcesses with the result sorted
+// by memory usage, highest first.
+func (a *App) ListProcessesSortedByMemory(sessionID string) ([]procman.Process, error) {
+	procs, err := a.ListProcesses(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	procman.SortByMemory(procs)
+	return procs, nil
+}
+
+// SendProcessSignal sends signal (e.g. "TERM", "KILL", "HUP") to pid on
+// the host behind sessionID.
+func (a *App) SendProcessSignal(sessionID string, pid int, signal string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	return procman.SendSignal(sess, pid, signal)
+}
+
+// ListServiceUnits returns every systemd service unit on the host
+// behind sessionID, for a services panel next to the terminal.
+func (a *App) ListServiceUnits(sessionID string) ([]svcmgr.Unit, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return svcmgr.ListUnits(sess)
+}
+
+// GetServiceStatus returns `systemctl status unit`'s output for the
+// host behind sessionID.
+func (a *App) GetServiceStatus(sessionID, unit string) (string, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return "", err
+	}
+	return svcmgr.Status(sess, unit)
+}
+
+// GetServiceJournal returns the last lines entries of unit's journal on
+// the host behind sessionID.
+func (a *App) GetServiceJournal(sessionID, unit string, lines int) (string, error) {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return "", err
+	}
+	return svcmgr.JournalExcerpt(sess, unit, lines)
+}
+
+// StartService starts unit on the host behind sessionID, via sudo if
+// sudoPassword is non-empty.
+func (a *App) StartService(sessionID, unit, sudoPassword string) error {
+	return a.applyServiceAction(sessionID, unit, svcmgr.Start, sudoPassword)
+}
+
+// StopService stops unit on the host behind sessionID, via sudo if
+// sudoPassword is non-empty.
+func (a *App) StopService(sessionID, unit, sudoPassword string) error {
+	return a.applyServiceAction(sessionID, unit, svcmgr.Stop, sudoPassword)
+}
+
+// RestartService restarts unit on the host behind sessionID, via sudo
+// if sudoPassword is non-empty.
+func (a *App) RestartService(sessionID, unit, sudoPassword string) error {
+	return a.applyServiceAction(sessionID, unit, svcmgr.Restart, sudoPassword)
+}
+
+// EnableService enables unit to start at boot on the host behind
+// sessionID, via sudo if sudoPassword is non-empty.
+func (a *App) EnableService(sessionID, unit, sudoPassword string) error {
+	return a.applyServiceAction(sessionID, unit, svcmgr.Enable, sudoPassword)
+}
+
+// DisableService disables unit from starting at boot on the host behind
+// sessionID, via sudo if sudoPassword is non-empty.
+func (a *App) DisableService(sessionID, unit, sudoPassword string) error {
+	return a.applyServiceAction(sessionID, unit, svcmgr.Disable, sudoPassword)
+}
+
+func (a *App) applyServiceAction(sessionID, unit string, action svcmgr.Action, sudoPassword string) error {
+	sess, err := a.sessionFor(sessionID)
+	if err != nil {
+		return err
+	}
+	if err := svcmgr.Apply(sess, unit, action, sudoPassword); err != nil {
+		return err
+	}
+
+	if action == svcmgr.Restart {
+		if err := audit.Record(audit.Entry{Host: sess.Host, Kind: audit.KindServiceRestart, Detail: unit, When: time.Now()}); err != nil {
+			println("failed to record service restart audit entry:", err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Package audit records a durable, append-only log of actions the app
+// takes against remote hosts (file edits, package installs, service
+// restarts, uploads), used as the source of truth for features like the
+// per-host change journal.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Kind enumerates the categories of action we audit.
+type Kind string
+
+const (
+	KindFileEdit       Kind = "file_edit"
+	KindPackageInstall Kind = "package_install"
+	KindServiceRestart Kind = "service_restart"
+	KindUpload         Kind = "upload"
+	KindCommand        Kind = "command"
+)
+
+// Entry is a single audited action.
+type Entry struct {
+	Host   string    `json:"host"`
+	Kind   Kind      `json:"kind"`
+	Detail string    `json:"detail"`
+	When   time.Time `json:"when"`
+}
+
+const logPath = "./history/audit.log"
+
+// Record appends entry to the audit log, one JSON object per line.
+func Record(entry Entry) error {
+	if err := os.MkdirAll(filepath.Dir(logPath), 0o755); err != nil {
+		return fmt.Errorf("create audit log dir: %w", err)
+	}
+
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode audit entry: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write audit entry: %w", err)
+	}
+	return nil
+}
+
+// ForHost returns every audited entry recorded for host, oldest first.
+func ForHost(host string) ([]Entry, error) {
+	f, err := os.Open(logPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if e.Host == host {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,166 @@
+// Package bundle exports and imports a portable archive of profiles,
+// saved commands, and cloud backend config, so a user can move to a new
+// laptop or share a team baseline without hand-copying individual
+// config files.
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"sshapp/internal/cloud"
+	"sshapp/internal/profile"
+	"sshapp/internal/savedcmd"
+	"sshapp/internal/vault"
+)
+
+// ExportOptions controls what Export includes.
+type ExportOptions struct {
+	// IncludeSecrets keeps plaintext Password fields in the bundle.
+	// Profiles using KeychainRef/VaultRef never carry their secret
+	// either way, since those point at a secret store on the exporting
+	// machine that the importing one won't have.
+	IncludeSecrets bool
+
+	// EncryptPassword, if set, encrypts the whole manifest with this
+	// password (see vault.EncryptBytes) instead of writing it as plain
+	// JSON inside the archive.
+	EncryptPassword string
+}
+
+type manifest struct {
+	Profiles []profile.Profile   `json:"profiles"`
+	Commands []*savedcmd.Command `json:"commands"`
+	Cloud    cloud.Config        `json:"cloud"`
+}
+
+const manifestEntry = "manifest.json"
+const encryptedManifestEntry = "manifest.enc"
+
+// Export builds a zip archive containing every profile, saved command,
+// and the cloud backend config.
+func Export(profiles *profile.Store, cmds *savedcmd.Store, opts ExportOptions) ([]byte, error) {
+	cfg, err := cloud.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	m := manifest{Cloud: cfg, Commands: cmds.List()}
+	for _, p := range profiles.List() {
+		pCopy := *p
+		if !opts.IncludeSecrets {
+			pCopy.Password = ""
+		}
+		m.Profiles = append(m.Profiles, pCopy)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode bundle manifest: %w", err)
+	}
+
+	entry := manifestEntry
+	if opts.EncryptPassword != "" {
+		data, err = vault.EncryptBytes(opts.EncryptPassword, data)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt bundle manifest: %w", err)
+		}
+		entry = encryptedManifestEntry
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(entry)
+	if err != nil {
+		return nil, fmt.Errorf("create bundle entry: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, fmt.Errorf("write bundle entry: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("close bundle: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Result reports what Import actually applied.
+type Result struct {
+	ProfilesImported []string
+	CommandsImported int
+	Warnings         []string
+}
+
+// Import reads a bundle produced by Export and applies it: profiles
+// that don't already exist by name are created, every saved command is
+// upserted by ID, and the cloud config is overwritten. One bad profile
+// or command doesn't block the rest of the import. decryptPassword is
+// only needed for a bundle exported with EncryptPassword set.
+func Import(data []byte, decryptPassword string, profiles *profile.Store, cmds *savedcmd.Store) (Result, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return Result{}, fmt.Errorf("open bundle: %w", err)
+	}
+
+	raw, encrypted, err := readManifestEntry(zr)
+	if err != nil {
+		return Result{}, err
+	}
+	if encrypted {
+		raw, err = vault.DecryptBytes(decryptPassword, raw)
+		if err != nil {
+			return Result{}, fmt.Errorf("decrypt bundle manifest: %w", err)
+		}
+	}
+
+	var m manifest
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return Result{}, fmt.Errorf("parse bundle manifest: %w", err)
+	}
+
+	var res Result
+	for _, p := range m.Profiles {
+		if err := profile.ValidateName(p.Name); err != nil {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("profile %q: %v", p.Name, err))
+			continue
+		}
+		if err := profiles.Create(p); err != nil {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("profile %q: %v", p.Name, err))
+			continue
+		}
+		res.ProfilesImported = append(res.ProfilesImported, p.Name)
+	}
+
+	for _, c := range m.Commands {
+		if err := cmds.Put(c); err != nil {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("command %q: %v", c.Name, err))
+			continue
+		}
+		res.CommandsImported++
+	}
+
+	if err := cloud.SaveConfig(m.Cloud); err != nil {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("cloud config: %v", err))
+	}
+
+	return res, nil
+}
+
+func readManifestEntry(zr *zip.Reader) (data []byte, encrypted bool, err error) {
+	if f, err := zr.Open(encryptedManifestEntry); err == nil {
+		defer f.Close()
+		data, err := io.ReadAll(f)
+		return data, true, err
+	}
+
+	f, err := zr.Open(manifestEntry)
+	if err != nil {
+		return nil, false, fmt.Errorf("bundle has no manifest: %w", err)
+	}
+	defer f.Close()
+
+	data, err = io.ReadAll(f)
+	return data, false, err
+}
@@ -0,0 +1,28 @@
+// Package capture writes terminal output to a local file, for commands
+// whose results are worth keeping outside the scrollback.
+package capture
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ToFile appends data to path, creating parent directories and the file
+// if necessary.
+func ToFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create capture dir: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open capture file %q: %w", path, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("write capture file %q: %w", path, err)
+	}
+	return nil
+}
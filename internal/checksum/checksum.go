@@ -0,0 +1,78 @@
+// Package checksum verifies file integrity after a transfer by
+// comparing SHA-256 digests computed on each end.
+package checksum
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"sshapp/internal/sshsession"
+)
+
+// Local computes the SHA-256 digest of a local file.
+func Local(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("open %q: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("hash %q: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Remote computes the SHA-256 digest of a file on sess's host, trying
+// sha256sum first and falling back to openssl for minimal images that
+// don't ship coreutils' sha256sum.
+func Remote(sess *sshsession.Session, path string) (string, error) {
+	quoted := shellQuote(path)
+	output, err := sess.Run(fmt.Sprintf("sha256sum %s 2>/dev/null || openssl dgst -sha256 %s", quoted, quoted))
+	if err != nil {
+		return "", fmt.Errorf("hash %q on %s: %w", path, sess.Host, err)
+	}
+	return parseDigest(output), nil
+}
+
+// parseDigest extracts the hex digest from either sha256sum's
+// "<digest>  <path>" output or openssl dgst's "SHA256(<path>)= <digest>"
+// output.
+func parseDigest(output string) string {
+	output = strings.TrimSpace(output)
+	if idx := strings.LastIndex(output, "= "); idx >= 0 {
+		return strings.TrimSpace(output[idx+2:])
+	}
+	if fields := strings.Fields(output); len(fields) > 0 {
+		return fields[0]
+	}
+	return output
+}
+
+// shellQuote single-quotes s for safe interpolation into a remote shell
+// command, escaping any single quotes it contains.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Verify compares a transfer's local and remote digests, returning an
+// error describing the mismatch if they differ.
+func Verify(sess *sshsession.Session, localPath, remotePath string) error {
+	local, err := Local(localPath)
+	if err != nil {
+		return err
+	}
+	remote, err := Remote(sess, remotePath)
+	if err != nil {
+		return err
+	}
+	if local != remote {
+		return fmt.Errorf("checksum mismatch: local %s (%s) != remote %s (%s)", localPath, local, remotePath, remote)
+	}
+	return nil
+}
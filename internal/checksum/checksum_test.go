@@ -0,0 +1,79 @@
+package checksum
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.txt")
+	if err := os.WriteFile(path, []byte("hello world"), 0o644); err != nil {
+		t.Fatalf("write test file: %v", err)
+	}
+
+	got, err := Local(path)
+	if err != nil {
+		t.Fatalf("Local: %v", err)
+	}
+	// sha256("hello world")
+	want := "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+	if got != want {
+		t.Errorf("Local() = %q, want %q", got, want)
+	}
+}
+
+func TestLocalMissingFile(t *testing.T) {
+	if _, err := Local(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Fatal("Local() on missing file: want error, got nil")
+	}
+}
+
+func TestParseDigest(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{
+			name:   "sha256sum format",
+			output: "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde  /tmp/data.txt\n",
+			want:   "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde",
+		},
+		{
+			name:   "openssl dgst format",
+			output: "SHA256(/tmp/data.txt)= b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde\n",
+			want:   "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde",
+		},
+		{
+			name:   "bare digest",
+			output: "  b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde  ",
+			want:   "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseDigest(tt.output); got != tt.want {
+				t.Errorf("parseDigest(%q) = %q, want %q", tt.output, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "plain", want: "'plain'"},
+		{in: "with space", want: "'with space'"},
+		{in: "it's quoted", want: `'it'\''s quoted'`},
+	}
+
+	for _, tt := range tests {
+		if got := shellQuote(tt.in); got != tt.want {
+			t.Errorf("shellQuote(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
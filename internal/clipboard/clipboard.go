@@ -0,0 +1,27 @@
+// Package clipboard bridges the remote session's clipboard operations to
+// the local machine, via the OSC 52 terminal escape sequence or, when a
+// remote helper is available, xclip/pbcopy over the session itself.
+package clipboard
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// OSC52Sequence wraps text in the OSC 52 escape sequence that terminal
+// emulators (including this app's own PTY view) use to set the local
+// system clipboard from remote output.
+func OSC52Sequence(text string) string {
+	encoded := base64.StdEncoding.EncodeToString([]byte(text))
+	return fmt.Sprintf("\x1b]52;c;%s\x07", encoded)
+}
+
+// RemoteCopyCommand returns a shell command that copies text into the
+// remote host's clipboard using whichever helper is available there.
+func RemoteCopyCommand(text string) string {
+	encoded := base64.StdEncoding.EncodeToString([]byte(text))
+	return fmt.Sprintf(
+		`echo %s | base64 -d | { command -v pbcopy >/dev/null && pbcopy || command -v xclip >/dev/null && xclip -selection clipboard || command -v xsel >/dev/null && xsel --clipboard --input; }`,
+		encoded,
+	)
+}
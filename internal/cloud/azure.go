@@ -0,0 +1,52 @@
+package cloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+)
+
+// AzureBackend lists VM instances via the az CLI.
+type AzureBackend struct {
+	ResourceGroup string // empty lists across every resource group
+}
+
+func (b AzureBackend) ListVMs() ([]VM, error) {
+	args := []string{"vm", "list", "-d", "-o", "json"}
+	if b.ResourceGroup != "" {
+		args = append(args, "--resource-group", b.ResourceGroup)
+	}
+
+	out, err := exec.Command("az", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("az vm list: %w", err)
+	}
+
+	var raw []struct {
+		Name       string `json:"name"`
+		Location   string `json:"location"`
+		PowerState string `json:"powerState"`
+		PublicIps  string `json:"publicIps"`
+		PrivateIps string `json:"privateIps"`
+	}
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, fmt.Errorf("parse az output: %w", err)
+	}
+
+	vms := make([]VM, 0, len(raw))
+	for _, r := range raw {
+		vm := VM{
+			Provider:  "azure",
+			Name:      r.Name,
+			Zone:      r.Location,
+			Status:    r.PowerState,
+			Host:      r.PublicIps,
+			PrivateIP: r.PrivateIps,
+		}
+		if vm.Host == "" {
+			vm.Host = vm.PrivateIP
+		}
+		vms = append(vms, vm)
+	}
+	return vms, nil
+}
@@ -0,0 +1,22 @@
+// Package cloud discovers VM instances from cloud providers, so they
+// can be turned into connection profiles instead of users copying IPs
+// by hand out of a cloud console. Each backend shells out to that
+// provider's own CLI (gcloud, az) rather than embedding its SDK, so
+// credentials are whatever the user already has that CLI authenticated
+// with.
+package cloud
+
+// VM is one discovered virtual machine, normalized across providers.
+type VM struct {
+	Provider  string // "gcp", "azure"
+	Name      string
+	Host      string // public IP/DNS if available, else PrivateIP
+	PrivateIP string
+	Zone      string
+	Status    string
+}
+
+// Backend discovers VMs from one cloud provider/project.
+type Backend interface {
+	ListVMs() ([]VM, error)
+}
@@ -0,0 +1,47 @@
+package cloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Config holds which project/resource group each backend should query.
+// It doesn't hold credentials itself — GCPBackend and AzureBackend use
+// whatever gcloud/az are already authenticated with.
+type Config struct {
+	GCPProject         string `json:"gcp_project,omitempty"`
+	AzureResourceGroup string `json:"azure_resource_group,omitempty"`
+}
+
+const configPath = "./configs/.cloud.json"
+
+// LoadConfig reads the saved cloud config, returning a zero Config if
+// it doesn't exist yet.
+func LoadConfig() (Config, error) {
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("read cloud config: %w", err)
+	}
+
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Config{}, fmt.Errorf("parse cloud config: %w", err)
+	}
+	return c, nil
+}
+
+// SaveConfig persists c to disk.
+func SaveConfig(c Config) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode cloud config: %w", err)
+	}
+	if err := os.WriteFile(configPath, data, 0o600); err != nil {
+		return fmt.Errorf("write cloud config: %w", err)
+	}
+	return nil
+}
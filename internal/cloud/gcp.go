@@ -0,0 +1,57 @@
+package cloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path"
+)
+
+// GCPBackend lists Compute Engine instances via the gcloud CLI.
+type GCPBackend struct {
+	Project string // empty uses gcloud's currently configured project
+}
+
+func (b GCPBackend) ListVMs() ([]VM, error) {
+	args := []string{"compute", "instances", "list", "--format=json"}
+	if b.Project != "" {
+		args = append(args, "--project", b.Project)
+	}
+
+	out, err := exec.Command("gcloud", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("gcloud compute instances list: %w", err)
+	}
+
+	var raw []struct {
+		Name              string `json:"name"`
+		Zone              string `json:"zone"`
+		Status            string `json:"status"`
+		NetworkInterfaces []struct {
+			NetworkIP     string `json:"networkIP"`
+			AccessConfigs []struct {
+				NatIP string `json:"natIP"`
+			} `json:"accessConfigs"`
+		} `json:"networkInterfaces"`
+	}
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, fmt.Errorf("parse gcloud output: %w", err)
+	}
+
+	vms := make([]VM, 0, len(raw))
+	for _, r := range raw {
+		vm := VM{Provider: "gcp", Name: r.Name, Zone: path.Base(r.Zone), Status: r.Status}
+		if len(r.NetworkInterfaces) > 0 {
+			ni := r.NetworkInterfaces[0]
+			vm.PrivateIP = ni.NetworkIP
+			if len(ni.AccessConfigs) > 0 {
+				vm.Host = ni.AccessConfigs[0].NatIP
+			}
+		}
+		if vm.Host == "" {
+			vm.Host = vm.PrivateIP
+		}
+		vms = append(vms, vm)
+	}
+	return vms, nil
+}
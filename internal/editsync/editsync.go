@@ -0,0 +1,138 @@
+// Package editsync implements the "edit remote file locally" workflow:
+// download a remote file to a temp directory, open it in the OS default
+// editor, and re-upload it whenever it changes on disk.
+package editsync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"sshapp/internal/openutil"
+	"sshapp/internal/remotefs"
+	"sshapp/internal/sshsession"
+)
+
+// Status is a point-in-time report on an open edit session, suitable
+// for streaming to the frontend as an event.
+type Status string
+
+const (
+	Downloaded Status = "downloaded"
+	Opened     Status = "opened"
+	Uploading  Status = "uploading"
+	Synced     Status = "synced"
+	Error      Status = "error"
+	Closed     Status = "closed"
+)
+
+// Event reports a status change for one open edit session.
+type Event struct {
+	RemotePath string
+	LocalPath  string
+	Status     Status
+	Err        string
+}
+
+const pollInterval = time.Second
+
+// Open downloads remotePath to a temp file, opens it in the OS default
+// editor, and watches it for changes; every change is re-uploaded to
+// remotePath. It returns immediately; onEvent is called for every status
+// change until stop is closed.
+func Open(sess *sshsession.Session, remotePath string, onEvent func(Event), stop <-chan struct{}) error {
+	localPath, err := download(sess, remotePath)
+	if err != nil {
+		onEvent(Event{RemotePath: remotePath, Status: Error, Err: err.Error()})
+		return err
+	}
+	onEvent(Event{RemotePath: remotePath, LocalPath: localPath, Status: Downloaded})
+
+	if err := openInDefaultEditor(localPath); err != nil {
+		onEvent(Event{RemotePath: remotePath, LocalPath: localPath, Status: Error, Err: err.Error()})
+		return err
+	}
+	onEvent(Event{RemotePath: remotePath, LocalPath: localPath, Status: Opened})
+
+	go watch(sess, remotePath, localPath, onEvent, stop)
+	return nil
+}
+
+func download(sess *sshsession.Session, remotePath string) (string, error) {
+	r, err := remotefs.Open(sess, remotePath, true)
+	if err != nil {
+		return "", fmt.Errorf("download %q: %w", remotePath, err)
+	}
+	defer r.Close()
+
+	dir, err := os.MkdirTemp("", "sshapp-edit-*")
+	if err != nil {
+		return "", fmt.Errorf("create temp dir: %w", err)
+	}
+
+	localPath := filepath.Join(dir, filepath.Base(remotePath))
+	f, err := os.Create(localPath)
+	if err != nil {
+		return "", fmt.Errorf("create %q: %w", localPath, err)
+	}
+	defer f.Close()
+
+	if _, err := f.ReadFrom(r); err != nil {
+		return "", fmt.Errorf("write %q: %w", localPath, err)
+	}
+	return localPath, nil
+}
+
+// openInDefaultEditor launches the OS default handler for localPath.
+func openInDefaultEditor(localPath string) error {
+	return openutil.Open(localPath)
+}
+
+// watch polls localPath for changes until stop is closed, re-uploading
+// it to remotePath each time its content changes.
+func watch(sess *sshsession.Session, remotePath, localPath string, onEvent func(Event), stop <-chan struct{}) {
+	lastMod, _ := modTime(localPath)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			onEvent(Event{RemotePath: remotePath, LocalPath: localPath, Status: Closed})
+			return
+		case <-ticker.C:
+			curr, err := modTime(localPath)
+			if err != nil || curr.Equal(lastMod) {
+				continue
+			}
+			lastMod = curr
+
+			onEvent(Event{RemotePath: remotePath, LocalPath: localPath, Status: Uploading})
+			if err := upload(sess, localPath, remotePath); err != nil {
+				onEvent(Event{RemotePath: remotePath, LocalPath: localPath, Status: Error, Err: err.Error()})
+				continue
+			}
+			onEvent(Event{RemotePath: remotePath, LocalPath: localPath, Status: Synced})
+		}
+	}
+}
+
+func modTime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+func upload(sess *sshsession.Session, localPath, remotePath string) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return fmt.Errorf("open %q: %w", localPath, err)
+	}
+	defer f.Close()
+
+	return remotefs.Write(sess, remotePath, f)
+}
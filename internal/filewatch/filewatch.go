@@ -0,0 +1,73 @@
+// Package filewatch polls a remote directory for changes and emits
+// add/remove/modify events, since most remote filesystems the app talks
+// to (over SFTP) have no inotify-equivalent we can subscribe to
+// directly.
+package filewatch
+
+import (
+	"time"
+)
+
+// ChangeKind describes what happened to an entry between polls.
+type ChangeKind string
+
+const (
+	Added    ChangeKind = "added"
+	Removed  ChangeKind = "removed"
+	Modified ChangeKind = "modified"
+)
+
+// Change is a single detected filesystem change.
+type Change struct {
+	Path string
+	Kind ChangeKind
+}
+
+// Lister lists the files in a remote directory along with their last
+// modified time, keyed by path.
+type Lister interface {
+	List(dir string) (map[string]time.Time, error)
+}
+
+// Watch polls dir via lister every interval until stop is closed, calling
+// onChange with every detected difference from the previous poll.
+func Watch(lister Lister, dir string, interval time.Duration, onChange func(Change), stop <-chan struct{}) error {
+	prev, err := lister.List(dir)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+			curr, err := lister.List(dir)
+			if err != nil {
+				continue
+			}
+			diff(prev, curr, onChange)
+			prev = curr
+		}
+	}
+}
+
+func diff(prev, curr map[string]time.Time, onChange func(Change)) {
+	for path, modTime := range curr {
+		prevMod, existed := prev[path]
+		switch {
+		case !existed:
+			onChange(Change{Path: path, Kind: Added})
+		case !prevMod.Equal(modTime):
+			onChange(Change{Path: path, Kind: Modified})
+		}
+	}
+	for path := range prev {
+		if _, stillThere := curr[path]; !stillThere {
+			onChange(Change{Path: path, Kind: Removed})
+		}
+	}
+}
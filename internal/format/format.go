@@ -0,0 +1,52 @@
+// Package format renders timestamps and byte sizes for display, honoring
+// the user's time zone (timestamps) and locale (sizes) instead of
+// hard-coding one convention.
+package format
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// Options controls how values are formatted.
+type Options struct {
+	Locale   string // BCP 47 tag, e.g. "en-US", "de-DE"
+	TimeZone string // IANA zone, e.g. "Asia/Kolkata"; "" means local
+}
+
+// Timestamp renders t in the given time zone using the fixed English
+// "Jan 2, 2006 3:04 PM" layout. opts.Locale does not change the output:
+// month names and field order are the same for every locale.
+func Timestamp(t time.Time, opts Options) string {
+	if opts.TimeZone != "" {
+		if loc, err := time.LoadLocation(opts.TimeZone); err == nil {
+			t = t.In(loc)
+		}
+	}
+
+	tag := language.Make(opts.Locale)
+	p := message.NewPrinter(tag)
+	return p.Sprintf("%s", t.Format("Jan 2, 2006 3:04 PM"))
+}
+
+// Size renders a byte count as a human-readable string (KB/MB/GB),
+// grouping the numeric part per the given locale.
+func Size(bytes int64, opts Options) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	tag := language.Make(opts.Locale)
+	p := message.NewPrinter(tag)
+	return p.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
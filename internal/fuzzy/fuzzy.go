@@ -0,0 +1,63 @@
+// Package fuzzy implements simple subsequence-based fuzzy matching used
+// to search saved snippets and command history.
+package fuzzy
+
+import "strings"
+
+// Match reports whether every rune in query appears in candidate, in
+// order, case-insensitively (a subsequence match, the same style used by
+// fuzzy file finders).
+func Match(query, candidate string) bool {
+	query = strings.ToLower(query)
+	candidate = strings.ToLower(candidate)
+
+	i := 0
+	for _, r := range candidate {
+		if i >= len(query) {
+			return true
+		}
+		if rune(query[i]) == r {
+			i++
+		}
+	}
+	return i >= len(query)
+}
+
+// Result is a candidate string that matched a fuzzy query, with a score
+// used to rank results (higher is a better match).
+type Result struct {
+	Value string
+	Score int
+}
+
+// Search filters candidates to those matching query and scores them by
+// how short and how early the match is, best matches first.
+func Search(query string, candidates []string) []Result {
+	var results []Result
+	for _, c := range candidates {
+		if !Match(query, c) {
+			continue
+		}
+		results = append(results, Result{Value: c, Score: score(query, c)})
+	}
+
+	for i := 1; i < len(results); i++ {
+		for j := i; j > 0 && results[j-1].Score < results[j].Score; j-- {
+			results[j-1], results[j] = results[j], results[j-1]
+		}
+	}
+	return results
+}
+
+// score rewards matches that are shorter overall and where the query
+// appears earlier.
+func score(query, candidate string) int {
+	s := 1000 - len(candidate)
+	if query == "" {
+		return s
+	}
+	if idx := strings.Index(strings.ToLower(candidate), strings.ToLower(query[:1])); idx >= 0 {
+		s -= idx
+	}
+	return s
+}
@@ -0,0 +1,74 @@
+// Package gitsync keeps the configs and saved-command directories under
+// version control in a git repository, so profiles and snippets can be
+// synced across machines via a normal git remote.
+package gitsync
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// Dirs lists the paths tracked for git-backed sync.
+var Dirs = []string{"./configs", "./history/saved_commands.json"}
+
+func run(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("git %v: %w: %s", args, err, out)
+	}
+	return string(out), nil
+}
+
+// Init creates a git repository in dir if one does not already exist.
+func Init(dir string) error {
+	_, err := run(dir, "rev-parse", "--is-inside-work-tree")
+	if err == nil {
+		return nil
+	}
+	_, err = run(dir, "init")
+	return err
+}
+
+// Commit stages every tracked path and commits with message. It is a
+// no-op (returns nil) if there is nothing to commit.
+func Commit(dir, message string) error {
+	args := []string{"add", "-A", "--"}
+	for _, p := range Dirs {
+		if _, err := os.Stat(filepath.Join(dir, p)); err == nil {
+			args = append(args, p)
+		}
+	}
+	if len(args) == 3 {
+		return nil
+	}
+	if _, err := run(dir, args...); err != nil {
+		return err
+	}
+
+	out, err := run(dir, "diff", "--cached", "--name-only")
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		return nil
+	}
+
+	_, err = run(dir, "commit", "-m", message)
+	return err
+}
+
+// Push pushes the current branch to remote.
+func Push(dir, remote string) error {
+	_, err := run(dir, "push", remote)
+	return err
+}
+
+// Pull fetches and merges from remote.
+func Pull(dir, remote string) error {
+	_, err := run(dir, "pull", remote)
+	return err
+}
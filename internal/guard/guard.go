@@ -0,0 +1,41 @@
+// Package guard flags destructive-looking commands before they are sent
+// to a remote session so the UI can ask for confirmation first.
+package guard
+
+import "regexp"
+
+// rule pairs a detection pattern with the warning shown to the user.
+type rule struct {
+	pattern *regexp.Regexp
+	warning string
+}
+
+// rules match commands that are commonly destructive. They are
+// intentionally conservative (favoring false positives) since the cost
+// of an extra confirmation is far lower than an un-confirmed rm -rf.
+var rules = []rule{
+	{regexp.MustCompile(`\brm\s+(-[a-zA-Z]*r[a-zA-Z]*f|-[a-zA-Z]*f[a-zA-Z]*r)\b`), "recursive force delete"},
+	{regexp.MustCompile(`\bmkfs(\.\w+)?\b`), "formats a filesystem, destroying existing data"},
+	{regexp.MustCompile(`\bdd\s+.*\bof=/dev/`), "writes raw data directly to a block device"},
+	{regexp.MustCompile(`:\(\)\s*\{\s*:\|:&\s*\};`), "looks like a fork bomb"},
+	{regexp.MustCompile(`\bshutdown\b`), "shuts the remote host down"},
+	{regexp.MustCompile(`\breboot\b`), "reboots the remote host"},
+	{regexp.MustCompile(`(?i)\bDROP\s+(TABLE|DATABASE)\b`), "drops a database/table"},
+	{regexp.MustCompile(`>\s*/dev/sd[a-z]`), "writes directly over a disk device"},
+}
+
+// Check returns a human-readable warning if command looks destructive,
+// or "" if it looks safe to run without extra confirmation.
+func Check(command string) string {
+	for _, r := range rules {
+		if r.pattern.MatchString(command) {
+			return r.warning
+		}
+	}
+	return ""
+}
+
+// IsDestructive reports whether Check would flag command.
+func IsDestructive(command string) bool {
+	return Check(command) != ""
+}
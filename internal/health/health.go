@@ -0,0 +1,61 @@
+// Package health checks connection profiles for basic reachability so
+// the UI can show a dashboard of which hosts are currently up.
+package health
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"sshapp/internal/profile"
+)
+
+// Status is one profile's last-checked health.
+type Status struct {
+	Profile   string
+	Reachable bool
+	LatencyMs int64
+	Err       string
+	CheckedAt time.Time
+}
+
+const dialTimeout = 3 * time.Second
+
+// Check dials host:port over TCP and reports whether it accepted a
+// connection within dialTimeout.
+func Check(profileName, host string, port int) Status {
+	start := time.Now()
+
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", host, port), dialTimeout)
+	status := Status{Profile: profileName, CheckedAt: start}
+
+	if err != nil {
+		status.Reachable = false
+		status.Err = err.Error()
+		return status
+	}
+	defer conn.Close()
+
+	status.Reachable = true
+	status.LatencyMs = time.Since(start).Milliseconds()
+	return status
+}
+
+// CheckAll runs Check for every profile concurrently and returns one
+// Status per profile, in the same order.
+func CheckAll(profiles []*profile.Profile) []Status {
+	results := make([]Status, len(profiles))
+	done := make(chan struct{}, len(profiles))
+
+	for i, p := range profiles {
+		i, p := i, p
+		go func() {
+			results[i] = Check(p.Name, p.Host, p.Port)
+			done <- struct{}{}
+		}()
+	}
+	for range profiles {
+		<-done
+	}
+	return results
+}
@@ -0,0 +1,82 @@
+// Package history manages per-profile command history, stored as plain
+// text files under ./history (see README), one command per line.
+package history
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const dir = "./history"
+
+func path(profileName string) string {
+	return filepath.Join(dir, profileName+"_history.txt")
+}
+
+// Append adds command to profileName's history file.
+func Append(profileName, command string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create history dir: %w", err)
+	}
+
+	f, err := os.OpenFile(path(profileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open history for %q: %w", profileName, err)
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintln(f, command); err != nil {
+		return fmt.Errorf("append history for %q: %w", profileName, err)
+	}
+	return nil
+}
+
+// Load returns profileName's command history, oldest first.
+func Load(profileName string) ([]string, error) {
+	f, err := os.Open(path(profileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open history for %q: %w", profileName, err)
+	}
+	defer f.Close()
+
+	var commands []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			commands = append(commands, line)
+		}
+	}
+	return commands, scanner.Err()
+}
+
+// LoadAll returns the combined history of every profile, keyed by
+// profile name.
+func LoadAll() (map[string][]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read history dir: %w", err)
+	}
+
+	const suffix = "_history.txt"
+	out := make(map[string][]string)
+	for _, e := range entries {
+		if e.IsDir() || len(e.Name()) <= len(suffix) || e.Name()[len(e.Name())-len(suffix):] != suffix {
+			continue
+		}
+		profileName := e.Name()[:len(e.Name())-len(suffix)]
+		commands, err := Load(profileName)
+		if err != nil {
+			return nil, err
+		}
+		out[profileName] = commands
+	}
+	return out, nil
+}
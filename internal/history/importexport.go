@@ -0,0 +1,35 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Export serializes profileName's history to a portable JSON document.
+func Export(profileName string) ([]byte, error) {
+	commands, err := Load(profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(commands, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode history export for %q: %w", profileName, err)
+	}
+	return data, nil
+}
+
+// Import appends the commands encoded in data to profileName's history.
+func Import(profileName string, data []byte) (int, error) {
+	var commands []string
+	if err := json.Unmarshal(data, &commands); err != nil {
+		return 0, fmt.Errorf("parse history import for %q: %w", profileName, err)
+	}
+
+	for _, c := range commands {
+		if err := Append(profileName, c); err != nil {
+			return 0, err
+		}
+	}
+	return len(commands), nil
+}
@@ -0,0 +1,103 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+const pinsPath = dir + "/pins.json"
+
+// PinStore tracks pinned history entries per profile. Pinned entries are
+// lightweight ad-hoc snippets: they're never archived away by Rotate and
+// are returned first by GetCommandHistory.
+type PinStore struct {
+	mu   sync.Mutex
+	pins map[string][]string
+}
+
+// LoadPins reads the pin store from disk, returning an empty store if it
+// doesn't exist yet.
+func LoadPins() (*PinStore, error) {
+	data, err := os.ReadFile(pinsPath)
+	if os.IsNotExist(err) {
+		return NewEmptyPins(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read pins: %w", err)
+	}
+
+	pins := make(map[string][]string)
+	if err := json.Unmarshal(data, &pins); err != nil {
+		return nil, fmt.Errorf("parse pins: %w", err)
+	}
+	return &PinStore{pins: pins}, nil
+}
+
+// NewEmptyPins returns a pin store with no pinned entries.
+func NewEmptyPins() *PinStore {
+	return &PinStore{pins: make(map[string][]string)}
+}
+
+func (p *PinStore) save() error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create history dir: %w", err)
+	}
+	data, err := json.MarshalIndent(p.pins, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode pins: %w", err)
+	}
+	if err := os.WriteFile(pinsPath, data, 0o644); err != nil {
+		return fmt.Errorf("write pins: %w", err)
+	}
+	return nil
+}
+
+// Pin marks command as pinned for profileName.
+func (p *PinStore) Pin(profileName, command string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, c := range p.pins[profileName] {
+		if c == command {
+			return nil
+		}
+	}
+	p.pins[profileName] = append(p.pins[profileName], command)
+	return p.save()
+}
+
+// Unpin removes command from profileName's pinned entries.
+func (p *PinStore) Unpin(profileName, command string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pinned := p.pins[profileName]
+	for i, c := range pinned {
+		if c == command {
+			p.pins[profileName] = append(pinned[:i], pinned[i+1:]...)
+			return p.save()
+		}
+	}
+	return nil
+}
+
+// List returns profileName's pinned entries.
+func (p *PinStore) List(profileName string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.pins[profileName]...)
+}
+
+// IsPinned reports whether command is pinned for profileName.
+func (p *PinStore) IsPinned(profileName, command string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, c := range p.pins[profileName] {
+		if c == command {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package history
+
+import "sort"
+
+// Ranked is a distinct history entry with how often it occurs.
+type Ranked struct {
+	Command string
+	Count   int
+}
+
+// Dedup collapses duplicate commands in commands, counting occurrences
+// and returning the distinct entries ranked by frequency (most frequent
+// first), ties broken by most recent occurrence.
+func Dedup(commands []string) []Ranked {
+	counts := make(map[string]int)
+	lastSeen := make(map[string]int)
+	for i, c := range commands {
+		counts[c]++
+		lastSeen[c] = i
+	}
+
+	ranked := make([]Ranked, 0, len(counts))
+	for c, n := range counts {
+		ranked = append(ranked, Ranked{Command: c, Count: n})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Count != ranked[j].Count {
+			return ranked[i].Count > ranked[j].Count
+		}
+		return lastSeen[ranked[i].Command] > lastSeen[ranked[j].Command]
+	})
+	return ranked
+}
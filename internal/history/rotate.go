@@ -0,0 +1,66 @@
+package history
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// MaxLines is the default number of lines a profile's history file may
+// grow to before Rotate archives the overflow. It can be overridden per
+// call to Rotate.
+const MaxLines = 10000
+
+// Rotate trims profileName's history file down to maxLines, moving the
+// older lines into a timestamped archive file in the same directory
+// rather than discarding them. Entries pinned in pins (nil is fine, and
+// treated as no pins) are never archived, regardless of position.
+func Rotate(profileName string, maxLines int, pins *PinStore) error {
+	if maxLines <= 0 {
+		maxLines = MaxLines
+	}
+
+	commands, err := Load(profileName)
+	if err != nil {
+		return err
+	}
+	if len(commands) <= maxLines {
+		return nil
+	}
+
+	overflow := len(commands) - maxLines
+	var archived, kept []string
+	for i, c := range commands {
+		if i < overflow && (pins == nil || !pins.IsPinned(profileName, c)) {
+			archived = append(archived, c)
+		} else {
+			kept = append(kept, c)
+		}
+	}
+
+	archivePath := filepath.Join(dir, fmt.Sprintf("%s_history_%s.archive.txt", profileName, time.Now().Format("20060102T150405")))
+	if err := writeLines(archivePath, archived); err != nil {
+		return fmt.Errorf("archive history for %q: %w", profileName, err)
+	}
+
+	if err := writeLines(path(profileName), kept); err != nil {
+		return fmt.Errorf("rewrite history for %q: %w", profileName, err)
+	}
+	return nil
+}
+
+func writeLines(p string, lines []string) error {
+	f, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(f, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
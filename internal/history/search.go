@@ -0,0 +1,31 @@
+package history
+
+import "strings"
+
+// Hit is a single matching history entry along with the profile it came
+// from.
+type Hit struct {
+	Profile string
+	Command string
+}
+
+// SearchAll scans every profile's history for commands containing query
+// as a substring, returning matches across all profiles.
+func SearchAll(query string) ([]Hit, error) {
+	all, err := LoadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.ToLower(query)
+
+	var hits []Hit
+	for profileName, commands := range all {
+		for _, c := range commands {
+			if strings.Contains(strings.ToLower(c), query) {
+				hits = append(hits, Hit{Profile: profileName, Command: c})
+			}
+		}
+	}
+	return hits, nil
+}
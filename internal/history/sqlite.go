@@ -0,0 +1,88 @@
+package history
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const sqlitePath = "./history/history.db"
+
+// SQLiteStore is an alternative history backend for installs that want
+// indexed search over a large history instead of the default per-profile
+// text files.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// OpenSQLiteStore opens (creating if necessary) the SQLite-backed
+// history database.
+func OpenSQLiteStore() (*SQLiteStore, error) {
+	if err := os.MkdirAll(filepath.Dir(sqlitePath), 0o755); err != nil {
+		return nil, fmt.Errorf("create history dir: %w", err)
+	}
+
+	db, err := sql.Open("sqlite3", sqlitePath)
+	if err != nil {
+		return nil, fmt.Errorf("open history database: %w", err)
+	}
+
+	const schema = `
+CREATE TABLE IF NOT EXISTS history (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	profile TEXT NOT NULL,
+	command TEXT NOT NULL,
+	ran_at DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_history_profile ON history(profile);
+`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create history schema: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+// Close releases the underlying database handle.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// Append records a command run against a profile.
+func (s *SQLiteStore) Append(profileName, command string) error {
+	_, err := s.db.Exec(
+		`INSERT INTO history (profile, command, ran_at) VALUES (?, ?, ?)`,
+		profileName, command, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("append history for %q: %w", profileName, err)
+	}
+	return nil
+}
+
+// Load returns profileName's history, oldest first.
+func (s *SQLiteStore) Load(profileName string) ([]string, error) {
+	rows, err := s.db.Query(
+		`SELECT command FROM history WHERE profile = ? ORDER BY id ASC`,
+		profileName,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("load history for %q: %w", profileName, err)
+	}
+	defer rows.Close()
+
+	var commands []string
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return nil, fmt.Errorf("scan history row: %w", err)
+		}
+		commands = append(commands, c)
+	}
+	return commands, rows.Err()
+}
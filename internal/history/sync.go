@@ -0,0 +1,65 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"sshapp/internal/vault"
+)
+
+// ExportEncrypted bundles every profile's history and encrypts it with
+// passphrase, producing a self-contained blob that can be carried to
+// another machine (e.g. via the git sync backend) and merged back in
+// with ImportEncrypted.
+func ExportEncrypted(passphrase string) ([]byte, error) {
+	bundle, err := LoadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("encode history bundle: %w", err)
+	}
+	return vault.EncryptBytes(passphrase, plaintext)
+}
+
+// ImportEncrypted decrypts data with passphrase and merges the bundled
+// history into the local per-profile history files. Merge is a union:
+// existing commands are kept in place, and any command from the bundle
+// not already present is appended, so importing never overwrites or
+// reorders local history.
+func ImportEncrypted(data []byte, passphrase string) error {
+	plaintext, err := vault.DecryptBytes(passphrase, data)
+	if err != nil {
+		return err
+	}
+
+	var bundle map[string][]string
+	if err := json.Unmarshal(plaintext, &bundle); err != nil {
+		return fmt.Errorf("decode history bundle: %w", err)
+	}
+
+	for profileName, incoming := range bundle {
+		existing, err := Load(profileName)
+		if err != nil {
+			return err
+		}
+
+		have := make(map[string]bool, len(existing))
+		for _, c := range existing {
+			have[c] = true
+		}
+
+		for _, c := range incoming {
+			if have[c] {
+				continue
+			}
+			if err := Append(profileName, c); err != nil {
+				return err
+			}
+			have[c] = true
+		}
+	}
+	return nil
+}
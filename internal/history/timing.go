@@ -0,0 +1,84 @@
+package history
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entry is one history line with when the command ran and how long it
+// took, encoded as "<unix-nanos>\t<duration-ns>\t<command>" so existing
+// plain-text history files remain readable (a line with no tabs is just
+// a bare command with no timing).
+type Entry struct {
+	Command string
+	RanAt   time.Time
+	Elapsed time.Duration
+}
+
+// AppendTimed records command along with when it ran and how long it
+// took.
+func AppendTimed(profileName string, entry Entry) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create history dir: %w", err)
+	}
+
+	f, err := os.OpenFile(path(profileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open history for %q: %w", profileName, err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "%d\t%d\t%s\n", entry.RanAt.UnixNano(), entry.Elapsed.Nanoseconds(), entry.Command)
+	if err != nil {
+		return fmt.Errorf("append timed history for %q: %w", profileName, err)
+	}
+	return nil
+}
+
+// LoadTimed returns profileName's history with timing information,
+// oldest first. Lines written by the untimed Append (no timing fields)
+// come back with a zero RanAt/Elapsed.
+func LoadTimed(profileName string) ([]Entry, error) {
+	f, err := os.Open(path(profileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open history for %q: %w", profileName, err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		entries = append(entries, parseTimedLine(line))
+	}
+	return entries, scanner.Err()
+}
+
+func parseTimedLine(line string) Entry {
+	parts := strings.SplitN(line, "\t", 3)
+	if len(parts) != 3 {
+		return Entry{Command: line}
+	}
+
+	ranAtNanos, err1 := strconv.ParseInt(parts[0], 10, 64)
+	elapsedNanos, err2 := strconv.ParseInt(parts[1], 10, 64)
+	if err1 != nil || err2 != nil {
+		return Entry{Command: line}
+	}
+
+	return Entry{
+		Command: parts[2],
+		RanAt:   time.Unix(0, ranAtNanos),
+		Elapsed: time.Duration(elapsedNanos),
+	}
+}
@@ -0,0 +1,60 @@
+// Package journal derives a reviewable per-host change journal from the
+// audit log, grouped into daily summaries suitable as lightweight change
+// management evidence.
+package journal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sshapp/internal/audit"
+)
+
+// DaySummary is every audited change made to a host on a single calendar
+// day.
+type DaySummary struct {
+	Date    string // YYYY-MM-DD
+	Entries []audit.Entry
+}
+
+// ForHost builds the full change journal for host, one DaySummary per
+// day that had at least one audited entry, oldest first.
+func ForHost(host string) ([]DaySummary, error) {
+	entries, err := audit.ForHost(host)
+	if err != nil {
+		return nil, fmt.Errorf("load audit entries for %q: %w", host, err)
+	}
+
+	byDay := make(map[string][]audit.Entry)
+	for _, e := range entries {
+		day := e.When.Format("2006-01-02")
+		byDay[day] = append(byDay[day], e)
+	}
+
+	days := make([]string, 0, len(byDay))
+	for day := range byDay {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+
+	summaries := make([]DaySummary, 0, len(days))
+	for _, day := range days {
+		summaries = append(summaries, DaySummary{Date: day, Entries: byDay[day]})
+	}
+	return summaries, nil
+}
+
+// Export renders the journal as plain text, one line per entry grouped
+// under a date heading, for copy/paste into change records.
+func Export(summaries []DaySummary) string {
+	var b strings.Builder
+	for _, day := range summaries {
+		fmt.Fprintf(&b, "## %s\n", day.Date)
+		for _, e := range day.Entries {
+			fmt.Fprintf(&b, "- [%s] %s: %s\n", e.When.Format("15:04:05"), e.Kind, e.Detail)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,90 @@
+// Package keychain stores secrets in the OS credential store instead
+// of plaintext config files: Keychain on macOS, libsecret on Linux,
+// Credential Manager on Windows. It shells out to each platform's own
+// CLI rather than linking a CGo keychain binding, matching how the rest
+// of this codebase reaches OS-specific functionality.
+package keychain
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// Set stores password under service/account, replacing any existing
+// entry with the same service and account.
+func Set(service, account, password string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		cmd := exec.Command("security", "add-generic-password", "-s", service, "-a", account, "-w", password, "-U")
+		return runQuiet(cmd)
+	case "windows":
+		// cmdkey has no update verb; deleting first keeps Set
+		// idempotent the same way the other platforms are.
+		exec.Command("cmdkey", "/delete:"+credTarget(service, account)).Run()
+		cmd := exec.Command("cmdkey", "/generic:"+credTarget(service, account), "/user:"+account, "/pass:"+password)
+		return runQuiet(cmd)
+	default:
+		cmd := exec.Command("secret-tool", "store", "--label="+service, "service", service, "account", account)
+		cmd.Stdin = bytes.NewReader([]byte(password))
+		return runQuiet(cmd)
+	}
+}
+
+// Get retrieves the password stored under service/account.
+func Get(service, account string) (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		cmd := exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w")
+		out, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("keychain lookup %s/%s: %w", service, account, err)
+		}
+		return trimNewline(out), nil
+	case "windows":
+		// Credential Manager's CLI (cmdkey) can store and delete
+		// generic credentials but, unlike Keychain and libsecret, has
+		// no verb to read a stored password back out.
+		return "", fmt.Errorf("reading a stored credential back isn't supported through cmdkey on Windows; re-enter the password")
+	default:
+		cmd := exec.Command("secret-tool", "lookup", "service", service, "account", account)
+		out, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("keychain lookup %s/%s: %w", service, account, err)
+		}
+		return trimNewline(out), nil
+	}
+}
+
+// Delete removes the entry stored under service/account, if any.
+func Delete(service, account string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		return runQuiet(exec.Command("security", "delete-generic-password", "-s", service, "-a", account))
+	case "windows":
+		return runQuiet(exec.Command("cmdkey", "/delete:"+credTarget(service, account)))
+	default:
+		return runQuiet(exec.Command("secret-tool", "clear", "service", service, "account", account))
+	}
+}
+
+func credTarget(service, account string) string {
+	return service + ":" + account
+}
+
+func runQuiet(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w: %s", cmd.Path, err, stderr.String())
+	}
+	return nil
+}
+
+func trimNewline(b []byte) string {
+	for len(b) > 0 && (b[len(b)-1] == '\n' || b[len(b)-1] == '\r') {
+		b = b[:len(b)-1]
+	}
+	return string(b)
+}
@@ -0,0 +1,110 @@
+// Package layout manages saved session templates: a fixed arrangement
+// of panes, each running a specific saved command against a profile, so
+// a whole debugging setup can be opened with one click.
+package layout
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Pane is one pane within a template.
+type Pane struct {
+	Profile   string `json:"profile"`
+	CommandID string `json:"command_id,omitempty"`
+	Row       int    `json:"row"`
+	Col       int    `json:"col"`
+}
+
+// Template is a named, reusable session layout ("debug bundle").
+type Template struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Panes []Pane `json:"panes"`
+}
+
+const storePath = "./configs/layouts.json"
+
+// Store persists session templates.
+type Store struct {
+	mu        sync.Mutex
+	templates map[string]*Template
+}
+
+// NewEmpty returns a Store with no templates loaded.
+func NewEmpty() *Store {
+	return &Store{templates: make(map[string]*Template)}
+}
+
+// Load reads saved templates from disk.
+func Load() (*Store, error) {
+	s := NewEmpty()
+
+	data, err := os.ReadFile(storePath)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read session templates: %w", err)
+	}
+
+	var list []*Template
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("parse session templates: %w", err)
+	}
+	for _, t := range list {
+		s.templates[t.ID] = t
+	}
+	return s, nil
+}
+
+func (s *Store) save() error {
+	list := make([]*Template, 0, len(s.templates))
+	for _, t := range s.templates {
+		list = append(list, t)
+	}
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode session templates: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(storePath), 0o755); err != nil {
+		return fmt.Errorf("create session templates dir: %w", err)
+	}
+	return os.WriteFile(storePath, data, 0o644)
+}
+
+// Put creates or updates a template.
+func (s *Store) Put(t *Template) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.templates[t.ID] = t
+	return s.save()
+}
+
+// List returns every saved template.
+func (s *Store) List() []*Template {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]*Template, 0, len(s.templates))
+	for _, t := range s.templates {
+		out = append(out, t)
+	}
+	return out
+}
+
+// Get returns the template with the given ID.
+func (s *Store) Get(id string) (*Template, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.templates[id]
+	if !ok {
+		return nil, fmt.Errorf("session template %q not found", id)
+	}
+	return t, nil
+}
@@ -0,0 +1,95 @@
+// Package logtail streams new lines appended to one or more remote
+// files via `tail -F`, optionally filtered remotely with grep, so
+// following a running service's logs doesn't require manually juggling
+// SSH shells and terminal tabs.
+package logtail
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// LineEvent is a single new line read from a tailed file.
+type LineEvent struct {
+	Profile string
+	File    string
+	Line    string
+}
+
+// ClientProvider exposes the *ssh.Client a tail runs over, satisfied by
+// *sshsession.Session.
+type ClientProvider interface {
+	UnderlyingClient() *ssh.Client
+}
+
+// Start runs `tail -F` against every path on sess's connection — one
+// exec session per path, so multi-file output never needs
+// disambiguating the way a single `tail -F a b` call's "==> file <=="
+// headers would — calling onLine for each line until stop is closed.
+// filter, if non-empty, is applied remotely via grep before lines reach
+// onLine, so a noisy log doesn't have to cross the wire just to be
+// discarded client-side. It returns once every tail has started
+// streaming.
+func Start(sess ClientProvider, profile string, paths []string, filter string, onLine func(LineEvent), stop <-chan struct{}) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("logtail: no paths given")
+	}
+
+	client := sess.UnderlyingClient()
+	if client == nil {
+		return fmt.Errorf("logtail: session not connected")
+	}
+
+	var sessions []*ssh.Session
+	for _, path := range paths {
+		s, err := client.NewSession()
+		if err != nil {
+			closeAll(sessions)
+			return fmt.Errorf("logtail: open session for %q: %w", path, err)
+		}
+
+		out, err := s.StdoutPipe()
+		if err != nil {
+			s.Close()
+			closeAll(sessions)
+			return fmt.Errorf("logtail: stdout pipe for %q: %w", path, err)
+		}
+
+		cmd := "tail -F " + shellQuoteArg(path)
+		if filter != "" {
+			cmd += " | grep --line-buffered " + shellQuoteArg(filter)
+		}
+		if err := s.Start(cmd); err != nil {
+			s.Close()
+			closeAll(sessions)
+			return fmt.Errorf("logtail: start tail for %q: %w", path, err)
+		}
+		sessions = append(sessions, s)
+
+		path, scanner := path, bufio.NewScanner(out)
+		go func() {
+			for scanner.Scan() {
+				onLine(LineEvent{Profile: profile, File: path, Line: scanner.Text()})
+			}
+		}()
+	}
+
+	go func() {
+		<-stop
+		closeAll(sessions)
+	}()
+	return nil
+}
+
+func closeAll(sessions []*ssh.Session) {
+	for _, s := range sessions {
+		s.Close()
+	}
+}
+
+func shellQuoteArg(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
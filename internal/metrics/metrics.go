@@ -0,0 +1,163 @@
+// Package metrics collects lightweight system metrics (CPU, memory,
+// disk, and load average) from connected sessions via cheap /proc,
+// vmstat, and df probes, for a live per-host dashboard next to the
+// terminal.
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Snapshot is one session's metrics as of the last probe.
+type Snapshot struct {
+	SessionID       string
+	CPUPercent      float64
+	MemUsedPercent  float64
+	DiskUsedPercent float64
+	LoadAvg1        float64
+	LoadAvg5        float64
+	LoadAvg15       float64
+	Err             string
+	CheckedAt       time.Time
+}
+
+// Runner executes a single command on a connected session and returns
+// its combined output, satisfied by *sshsession.Session.
+type Runner interface {
+	Run(command string) (string, error)
+}
+
+// probeScript reads /proc/loadavg, total/used memory (MB), total/used
+// disk (KB, root filesystem), and CPU idle% (from a 1-second vmstat
+// sample) in a single remote exec, separated by "---" so Collect can
+// split the output without needing four round trips.
+const probeScript = `cat /proc/loadavg; echo '---'; free -m | awk 'NR==2{print $2, $3}'; echo '---'; df -P / | awk 'NR==2{print $2, $3}'; echo '---'; vmstat 1 2 | tail -1 | awk '{print $15}'`
+
+// Collect runs the metrics probe against r and parses its output into a
+// Snapshot for sessionID. A probe or parse failure is reported in
+// Snapshot.Err rather than returned as an error, matching health.Check,
+// since a poller collecting many sessions wants one bad host to show up
+// in its dashboard rather than abort the batch.
+func Collect(sessionID string, r Runner) Snapshot {
+	snap := Snapshot{SessionID: sessionID, CheckedAt: time.Now()}
+
+	out, err := r.Run(probeScript)
+	if err != nil {
+		snap.Err = err.Error()
+		return snap
+	}
+
+	sections := strings.Split(out, "---")
+	if len(sections) != 4 {
+		snap.Err = fmt.Sprintf("unexpected probe output: %q", out)
+		return snap
+	}
+
+	if err := parseLoadAvg(sections[0], &snap); err != nil {
+		snap.Err = err.Error()
+		return snap
+	}
+	if err := parseMem(sections[1], &snap); err != nil {
+		snap.Err = err.Error()
+		return snap
+	}
+	if err := parseDisk(sections[2], &snap); err != nil {
+		snap.Err = err.Error()
+		return snap
+	}
+	if err := parseCPU(sections[3], &snap); err != nil {
+		snap.Err = err.Error()
+		return snap
+	}
+	return snap
+}
+
+func parseLoadAvg(s string, snap *Snapshot) error {
+	fields := strings.Fields(s)
+	if len(fields) < 3 {
+		return fmt.Errorf("parse load average %q: not enough fields", s)
+	}
+	var err error
+	if snap.LoadAvg1, err = strconv.ParseFloat(fields[0], 64); err != nil {
+		return fmt.Errorf("parse load average %q: %w", s, err)
+	}
+	if snap.LoadAvg5, err = strconv.ParseFloat(fields[1], 64); err != nil {
+		return fmt.Errorf("parse load average %q: %w", s, err)
+	}
+	if snap.LoadAvg15, err = strconv.ParseFloat(fields[2], 64); err != nil {
+		return fmt.Errorf("parse load average %q: %w", s, err)
+	}
+	return nil
+}
+
+func parseMem(s string, snap *Snapshot) error {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return fmt.Errorf("parse memory %q: not enough fields", s)
+	}
+	total, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return fmt.Errorf("parse memory %q: %w", s, err)
+	}
+	used, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return fmt.Errorf("parse memory %q: %w", s, err)
+	}
+	if total > 0 {
+		snap.MemUsedPercent = used / total * 100
+	}
+	return nil
+}
+
+func parseDisk(s string, snap *Snapshot) error {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return fmt.Errorf("parse disk usage %q: not enough fields", s)
+	}
+	total, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return fmt.Errorf("parse disk usage %q: %w", s, err)
+	}
+	used, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return fmt.Errorf("parse disk usage %q: %w", s, err)
+	}
+	if total > 0 {
+		snap.DiskUsedPercent = used / total * 100
+	}
+	return nil
+}
+
+func parseCPU(s string, snap *Snapshot) error {
+	idle, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return fmt.Errorf("parse cpu idle %q: %w", s, err)
+	}
+	snap.CPUPercent = 100 - idle
+	return nil
+}
+
+// CollectAll runs Collect for every (sessionID, Runner) pair
+// concurrently and returns one Snapshot per session, in no particular
+// order.
+func CollectAll(runners map[string]Runner) []Snapshot {
+	results := make([]Snapshot, len(runners))
+	done := make(chan struct{}, len(runners))
+
+	i := 0
+	for sessionID, r := range runners {
+		i, sessionID, r := i, sessionID, r
+		go func() {
+			results[i] = Collect(sessionID, r)
+			done <- struct{}{}
+		}()
+		i++
+	}
+	for range runners {
+		<-done
+	}
+	return results
+}
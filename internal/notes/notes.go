@@ -0,0 +1,93 @@
+// Package notes stores free-form markdown documentation per connection
+// profile ("this box needs service foo restarted after deploys"),
+// alongside the profile's own config, with a simple revision history.
+package notes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// baseDir is the root of every profile's notes directory, nested under
+// configs so notes travel with the profile they document.
+const baseDir = "./configs/notes"
+
+// Revision is one saved version of a host's notes.
+type Revision struct {
+	Content string
+	SavedAt time.Time
+}
+
+func profileDir(host string) string {
+	return filepath.Join(baseDir, host)
+}
+
+// GetHostNotes returns the current notes for host, or an empty string if
+// none have been saved yet.
+func GetHostNotes(host string) (string, error) {
+	path := filepath.Join(profileDir(host), "current.md")
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read notes for %q: %w", host, err)
+	}
+	return string(data), nil
+}
+
+// SaveHostNotes writes content as the current notes for host, archiving
+// the previous version into the host's revision history first.
+func SaveHostNotes(host, content string) error {
+	dir := profileDir(host)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create notes dir for %q: %w", host, err)
+	}
+
+	currentPath := filepath.Join(dir, "current.md")
+	if prev, err := os.ReadFile(currentPath); err == nil && len(prev) > 0 {
+		revPath := filepath.Join(dir, fmt.Sprintf("%d.md", time.Now().UnixNano()))
+		if err := os.WriteFile(revPath, prev, 0o644); err != nil {
+			return fmt.Errorf("archive previous notes for %q: %w", host, err)
+		}
+	}
+
+	if err := os.WriteFile(currentPath, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("write notes for %q: %w", host, err)
+	}
+	return nil
+}
+
+// ListRevisions returns the archived revisions for host, oldest first.
+func ListRevisions(host string) ([]Revision, error) {
+	dir := profileDir(host)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list revisions for %q: %w", host, err)
+	}
+
+	var revs []Revision
+	for _, e := range entries {
+		if e.IsDir() || e.Name() == "current.md" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		revs = append(revs, Revision{Content: string(data), SavedAt: info.ModTime()})
+	}
+
+	sort.Slice(revs, func(i, j int) bool { return revs[i].SavedAt.Before(revs[j].SavedAt) })
+	return revs, nil
+}
@@ -0,0 +1,29 @@
+// Package openutil launches the OS's default handler for a local file
+// path or URL, shared by anything that needs to hand something off to
+// the user's desktop environment (an edited file, a previewed web app).
+package openutil
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// Open launches the OS default handler for target: `open` on macOS,
+// `xdg-open` on Linux, `start` (via cmd) on Windows. target can be a
+// local file path or a URL.
+func Open(target string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "windows":
+		cmd = exec.Command("cmd", "/C", "start", "", target)
+	default:
+		cmd = exec.Command("xdg-open", target)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("open %q: %w", target, err)
+	}
+	return nil
+}
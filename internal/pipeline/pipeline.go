@@ -0,0 +1,59 @@
+// Package pipeline runs an ordered list of commands against a profile,
+// such as a build -> copy -> restart deploy sequence, reporting progress
+// as each step completes.
+package pipeline
+
+import "fmt"
+
+// Runner executes a single command against a remote host and returns its
+// combined output and any execution error.
+type Runner interface {
+	Run(command string) (output string, err error)
+}
+
+// Step is one command in a pipeline.
+type Step struct {
+	Command string
+	// ContinueOnFailure lets the pipeline keep going past this step's
+	// failure instead of aborting the rest of the run.
+	ContinueOnFailure bool
+}
+
+// Progress describes the outcome of a single step, reported as the
+// pipeline runs.
+type Progress struct {
+	StepIndex int
+	Total     int
+	Command   string
+	Output    string
+	Err       error
+}
+
+// Run executes steps in order on runner, calling onProgress after each
+// one. It stops at the first step that fails unless that step is marked
+// ContinueOnFailure, and returns the error of the step that stopped it
+// (nil if every step that mattered succeeded).
+func Run(runner Runner, steps []Step, onProgress func(Progress)) error {
+	for i, step := range steps {
+		output, err := runner.Run(step.Command)
+
+		if onProgress != nil {
+			onProgress(Progress{
+				StepIndex: i,
+				Total:     len(steps),
+				Command:   step.Command,
+				Output:    output,
+				Err:       err,
+			})
+		}
+
+		if err != nil {
+			if step.ContinueOnFailure {
+				continue
+			}
+			return fmt.Errorf("pipeline step %d (%q): %w", i, step.Command, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+// Package poller runs background polling tasks (health checks, directory
+// watches, metrics refreshes) on a shared, throttleable cadence so
+// features don't each spin up their own uncoordinated timers.
+package poller
+
+import (
+	"sync"
+	"time"
+)
+
+// Poller runs fn repeatedly with a pause of at least interval between
+// runs. The interval can be changed at runtime via SetInterval to ease
+// off when the app is busy or the window is backgrounded.
+type Poller struct {
+	fn func()
+
+	mu       sync.Mutex
+	interval time.Duration
+	stop     chan struct{}
+}
+
+// New returns a Poller that calls fn every interval, but does not start
+// running until Start is called.
+func New(interval time.Duration, fn func()) *Poller {
+	return &Poller{fn: fn, interval: interval}
+}
+
+// Start begins polling in the background. Calling Start twice without an
+// intervening Stop is a no-op.
+func (p *Poller) Start() {
+	p.mu.Lock()
+	if p.stop != nil {
+		p.mu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	p.stop = stop
+	p.mu.Unlock()
+
+	go p.loop(stop)
+}
+
+func (p *Poller) loop(stop chan struct{}) {
+	for {
+		p.mu.Lock()
+		interval := p.interval
+		p.mu.Unlock()
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+			p.fn()
+		}
+	}
+}
+
+// Stop halts polling. It is safe to call Stop more than once.
+func (p *Poller) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stop == nil {
+		return
+	}
+	close(p.stop)
+	p.stop = nil
+}
+
+// SetInterval changes the polling cadence; it takes effect after the
+// current wait completes.
+func (p *Poller) SetInterval(interval time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.interval = interval
+}
+
+// Throttle multiplies the current interval by factor (e.g. 2 to halve
+// the polling rate), useful when backing off under load.
+func (p *Poller) Throttle(factor float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.interval = time.Duration(float64(p.interval) * factor)
+}
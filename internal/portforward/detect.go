@@ -0,0 +1,39 @@
+package portforward
+
+import "regexp"
+
+// addrPattern matches host:port pairs (e.g. "listening on 0.0.0.0:8080",
+// "bound to localhost:3000") that commonly appear in command output when
+// a dev server or service starts up.
+var addrPattern = regexp.MustCompile(`\b((?:\d{1,3}\.){3}\d{1,3}|localhost)[:]([0-9]{2,5})\b`)
+
+// Suggestion is a forward the command palette can offer to the user,
+// derived from output mentioning a listening address.
+type Suggestion struct {
+	Host string
+	Port int
+}
+
+// DetectForwardable scans output for addresses that look like a service
+// just started listening, returning one Suggestion per distinct
+// host:port found.
+func DetectForwardable(output string) []Suggestion {
+	matches := addrPattern.FindAllStringSubmatch(output, -1)
+
+	seen := make(map[string]bool)
+	var out []Suggestion
+	for _, m := range matches {
+		key := m[1] + ":" + m[2]
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		port := 0
+		for _, c := range m[2] {
+			port = port*10 + int(c-'0')
+		}
+		out = append(out, Suggestion{Host: m[1], Port: port})
+	}
+	return out
+}
@@ -0,0 +1,117 @@
+package portforward
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+const savedDir = "./forwards"
+const savedPath = savedDir + "/saved.json"
+
+// SavedForward is a forward configuration persisted per profile, so it
+// can be re-established automatically instead of the user recreating it
+// by hand every time they reconnect.
+type SavedForward struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	LocalPort   int    `json:"local_port"`
+	RemoteHost  string `json:"remote_host"`
+	RemotePort  int    `json:"remote_port"`
+}
+
+// RestoreResult is one saved forward's outcome from an attempt to
+// re-establish it, suitable for streaming to the frontend as a
+// "forward_restored" event per forward.
+type RestoreResult struct {
+	Name        string
+	Description string
+	LocalPort   int
+	RemoteHost  string
+	RemotePort  int
+	Err         string // empty on success
+}
+
+// ForwardStore tracks saved forwards per profile.
+type ForwardStore struct {
+	mu    sync.Mutex
+	saved map[string][]SavedForward
+}
+
+// LoadForwardStore reads the saved-forwards store from disk, returning
+// an empty store if it doesn't exist yet.
+func LoadForwardStore() (*ForwardStore, error) {
+	data, err := os.ReadFile(savedPath)
+	if os.IsNotExist(err) {
+		return NewEmptyForwardStore(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read saved forwards: %w", err)
+	}
+
+	saved := make(map[string][]SavedForward)
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("parse saved forwards: %w", err)
+	}
+	return &ForwardStore{saved: saved}, nil
+}
+
+// NewEmptyForwardStore returns a store with no saved forwards.
+func NewEmptyForwardStore() *ForwardStore {
+	return &ForwardStore{saved: make(map[string][]SavedForward)}
+}
+
+func (s *ForwardStore) save() error {
+	if err := os.MkdirAll(savedDir, 0o755); err != nil {
+		return fmt.Errorf("create forwards dir: %w", err)
+	}
+	data, err := json.MarshalIndent(s.saved, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode saved forwards: %w", err)
+	}
+	if err := os.WriteFile(savedPath, data, 0o644); err != nil {
+		return fmt.Errorf("write saved forwards: %w", err)
+	}
+	return nil
+}
+
+// Save persists f under profileName, replacing any saved forward with
+// the same local port.
+func (s *ForwardStore) Save(profileName string, f SavedForward) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	forwards := s.saved[profileName]
+	for i, existing := range forwards {
+		if existing.LocalPort == f.LocalPort {
+			forwards[i] = f
+			s.saved[profileName] = forwards
+			return s.save()
+		}
+	}
+	s.saved[profileName] = append(forwards, f)
+	return s.save()
+}
+
+// Remove deletes profileName's saved forward on localPort, if any.
+func (s *ForwardStore) Remove(profileName string, localPort int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	forwards := s.saved[profileName]
+	for i, f := range forwards {
+		if f.LocalPort == localPort {
+			s.saved[profileName] = append(forwards[:i], forwards[i+1:]...)
+			return s.save()
+		}
+	}
+	return nil
+}
+
+// List returns profileName's saved forwards.
+func (s *ForwardStore) List(profileName string) []SavedForward {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SavedForward(nil), s.saved[profileName]...)
+}
@@ -0,0 +1,356 @@
+// Package portforward manages local<->remote SSH port forwards.
+package portforward
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Forward describes one active port forward.
+type Forward struct {
+	ID          string
+	Name        string // optional, user-facing label
+	Description string // optional
+	Profile     string
+	LocalPort   int
+	RemoteHost  string
+	RemotePort  int
+
+	listenerMu sync.Mutex
+	listener   net.Listener
+
+	connsMu sync.Mutex
+	conns   map[net.Conn]struct{}
+
+	bytesIn  int64 // atomic
+	bytesOut int64 // atomic
+
+	activityMu   sync.Mutex
+	lastActivity time.Time
+
+	stopping int32 // atomic; set before Stop closes the listener deliberately
+}
+
+// PortConflictError reports that a forward couldn't bind its local
+// port because something else is already using it, so the UI can offer
+// "use another port" instead of surfacing a raw bind error.
+// ConflictingForwardID is set when the conflict is with another forward
+// this Manager already has active; it's empty when the port is held by
+// some other process entirely.
+type PortConflictError struct {
+	Port                 int
+	ConflictingForwardID string
+}
+
+func (e *PortConflictError) Error() string {
+	if e.ConflictingForwardID != "" {
+		return fmt.Sprintf("port %d is already in use by forward %q", e.Port, e.ConflictingForwardID)
+	}
+	return fmt.Sprintf("port %d is already in use", e.Port)
+}
+
+// HealthStatus describes a change in a forward's health, reported
+// through a Manager's onHealth callback.
+type HealthStatus string
+
+const (
+	HealthDegraded HealthStatus = "degraded" // listener died unexpectedly; retrying
+	HealthRestored HealthStatus = "restored" // listener came back up after degrading
+	HealthFailed   HealthStatus = "failed"   // gave up retrying; forward is gone
+)
+
+// HealthEvent reports one forward's health transition.
+type HealthEvent struct {
+	ForwardID string
+	Status    HealthStatus
+	Err       string
+}
+
+// Info is a point-in-time snapshot of a Forward, including traffic
+// stats, suitable for returning to the frontend.
+type Info struct {
+	ID           string
+	Name         string
+	Description  string
+	Profile      string
+	LocalPort    int
+	RemoteHost   string
+	RemotePort   int
+	BytesIn      int64
+	BytesOut     int64
+	ActiveConns  int
+	LastActivity time.Time
+}
+
+// Info snapshots f's current state, including traffic stats.
+func (f *Forward) Info() Info {
+	f.connsMu.Lock()
+	active := len(f.conns)
+	f.connsMu.Unlock()
+
+	f.activityMu.Lock()
+	last := f.lastActivity
+	f.activityMu.Unlock()
+
+	return Info{
+		ID:           f.ID,
+		Name:         f.Name,
+		Description:  f.Description,
+		Profile:      f.Profile,
+		LocalPort:    f.LocalPort,
+		RemoteHost:   f.RemoteHost,
+		RemotePort:   f.RemotePort,
+		BytesIn:      atomic.LoadInt64(&f.bytesIn),
+		BytesOut:     atomic.LoadInt64(&f.bytesOut),
+		ActiveConns:  active,
+		LastActivity: last,
+	}
+}
+
+func (f *Forward) touch() {
+	f.activityMu.Lock()
+	f.lastActivity = time.Now()
+	f.activityMu.Unlock()
+}
+
+func (f *Forward) setListener(ln net.Listener) {
+	f.listenerMu.Lock()
+	f.listener = ln
+	f.listenerMu.Unlock()
+}
+
+func (f *Forward) getListener() net.Listener {
+	f.listenerMu.Lock()
+	defer f.listenerMu.Unlock()
+	return f.listener
+}
+
+// Manager owns the set of active forwards for a connection.
+type Manager struct {
+	client   *ssh.Client
+	onHealth func(HealthEvent)
+
+	mu       sync.Mutex
+	forwards map[string]*Forward
+}
+
+// New returns a Manager that tunnels forwards through client, calling
+// onHealth (if non-nil) whenever a forward degrades, restores, or fails
+// for good.
+func New(client *ssh.Client, onHealth func(HealthEvent)) *Manager {
+	return &Manager{client: client, onHealth: onHealth, forwards: make(map[string]*Forward)}
+}
+
+func (m *Manager) emitHealth(f *Forward, status HealthStatus, err error) {
+	if m.onHealth == nil {
+		return
+	}
+	e := HealthEvent{ForwardID: f.ID, Status: status}
+	if err != nil {
+		e.Err = err.Error()
+	}
+	m.onHealth(e)
+}
+
+// Start opens a local listener on localPort and forwards every
+// connection to remoteHost:remotePort through the SSH client. A
+// LocalPort of 0 asks the OS to pick a free port; f.LocalPort is
+// updated to whichever port actually ended up listening.
+func (m *Manager) Start(f *Forward) error {
+	if f.LocalPort != 0 {
+		if conflict := m.conflictingForward(f.LocalPort); conflict != "" {
+			return &PortConflictError{Port: f.LocalPort, ConflictingForwardID: conflict}
+		}
+	}
+
+	addr := fmt.Sprintf("127.0.0.1:%d", f.LocalPort)
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		if errors.Is(err, syscall.EADDRINUSE) {
+			return &PortConflictError{Port: f.LocalPort}
+		}
+		return fmt.Errorf("forward %q: listen on %s: %w", f.ID, addr, err)
+	}
+	f.LocalPort = ln.Addr().(*net.TCPAddr).Port
+	f.setListener(ln)
+	f.conns = make(map[net.Conn]struct{})
+
+	m.mu.Lock()
+	m.forwards[f.ID] = f
+	m.mu.Unlock()
+
+	go m.accept(f)
+	return nil
+}
+
+// conflictingForward returns the ID of an already-active forward bound
+// to localPort, or "" if none.
+func (m *Manager) conflictingForward(localPort int) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, f := range m.forwards {
+		if f.LocalPort == localPort {
+			return id
+		}
+	}
+	return ""
+}
+
+func (m *Manager) accept(f *Forward) {
+	for {
+		conn, err := f.getListener().Accept()
+		if err != nil {
+			if atomic.LoadInt32(&f.stopping) == 0 {
+				m.recover(f, err)
+			}
+			return
+		}
+		go m.pipe(f, conn)
+	}
+}
+
+// healthRetryDelays is how long to wait between successive restart
+// attempts after a forward's listener dies unexpectedly, e.g. because
+// the machine's network dropped out from under it.
+var healthRetryDelays = []time.Duration{time.Second, 3 * time.Second, 10 * time.Second}
+
+// recover tries to re-establish f's listener after it died outside of a
+// deliberate Stop, so long-lived tunnels survive a flaky network
+// instead of silently going dark. It bails out at every opportunity if
+// Stop raced it and already marked f as stopping, so a forward the
+// user asked to stop can never come back from under them.
+func (m *Manager) recover(f *Forward, cause error) {
+	m.emitHealth(f, HealthDegraded, cause)
+
+	for _, delay := range healthRetryDelays {
+		time.Sleep(delay)
+
+		if atomic.LoadInt32(&f.stopping) != 0 {
+			return
+		}
+
+		ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", f.LocalPort))
+		if err != nil {
+			continue
+		}
+
+		if atomic.LoadInt32(&f.stopping) != 0 {
+			ln.Close()
+			return
+		}
+
+		f.setListener(ln)
+		m.emitHealth(f, HealthRestored, nil)
+		go m.accept(f)
+		return
+	}
+
+	m.mu.Lock()
+	delete(m.forwards, f.ID)
+	m.mu.Unlock()
+	m.emitHealth(f, HealthFailed, cause)
+}
+
+func (m *Manager) pipe(f *Forward, local net.Conn) {
+	f.connsMu.Lock()
+	f.conns[local] = struct{}{}
+	f.connsMu.Unlock()
+	defer func() {
+		f.connsMu.Lock()
+		delete(f.conns, local)
+		f.connsMu.Unlock()
+		local.Close()
+	}()
+
+	remote, err := m.client.Dial("tcp", fmt.Sprintf("%s:%d", f.RemoteHost, f.RemotePort))
+	if err != nil {
+		return
+	}
+	defer remote.Close()
+
+	done := make(chan struct{}, 2)
+	go func() { f.countingCopy(remote, local, &f.bytesOut); done <- struct{}{} }()
+	go func() { f.countingCopy(local, remote, &f.bytesIn); done <- struct{}{} }()
+	<-done
+}
+
+// countingCopy is io.Copy, but adds every byte moved to counter and
+// bumps f's last-activity time, so traffic stats stay current without
+// the caller having to instrument each side separately.
+func (f *Forward) countingCopy(dst io.Writer, src io.Reader, counter *int64) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := src.Read(buf)
+		if n > 0 {
+			if _, writeErr := dst.Write(buf[:n]); writeErr != nil {
+				return
+			}
+			atomic.AddInt64(counter, int64(n))
+			f.touch()
+		}
+		if readErr != nil {
+			return
+		}
+	}
+}
+
+// Stop closes a forward's listener, stops accepting new connections, and
+// closes every connection already relaying through it, so the forward
+// actually goes away instead of continuing to serve in-flight traffic.
+func (m *Manager) Stop(id string) error {
+	m.mu.Lock()
+	f, ok := m.forwards[id]
+	if ok {
+		delete(m.forwards, id)
+	}
+	m.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("forward %q not found", id)
+	}
+
+	atomic.StoreInt32(&f.stopping, 1)
+	err := f.getListener().Close()
+
+	f.connsMu.Lock()
+	for conn := range f.conns {
+		conn.Close()
+	}
+	f.connsMu.Unlock()
+
+	return err
+}
+
+// Get returns a snapshot of the forward with the given ID.
+func (m *Manager) Get(id string) (Info, error) {
+	m.mu.Lock()
+	f, ok := m.forwards[id]
+	m.mu.Unlock()
+
+	if !ok {
+		return Info{}, fmt.Errorf("forward %q not found", id)
+	}
+	return f.Info(), nil
+}
+
+// List returns a snapshot of every active forward, including traffic
+// stats.
+func (m *Manager) List() []Info {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]Info, 0, len(m.forwards))
+	for _, f := range m.forwards {
+		out = append(out, f.Info())
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package portforward
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"sshapp/internal/openutil"
+)
+
+// previewPollInterval and previewTimeout bound how long ForwardAndOpen
+// waits for a freshly-forwarded service to start answering HTTP
+// requests before giving up.
+const (
+	previewPollInterval = 200 * time.Millisecond
+	previewTimeout      = 10 * time.Second
+)
+
+// ForwardAndOpen starts a forward exactly like Start, then polls the
+// local port with plain HTTP GETs until something answers (or
+// previewTimeout elapses), then opens the local URL in the user's
+// default browser. It's meant for the "preview what's running on the
+// server" one-click flow, so any HTTP response at all — including
+// redirects or error pages — counts as "ready"; only a refused or
+// timed-out connection means "not ready yet".
+func (m *Manager) ForwardAndOpen(f *Forward) error {
+	if err := m.Start(f); err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", f.LocalPort)
+	if !waitForHTTP(url, previewTimeout) {
+		m.Stop(f.ID)
+		return fmt.Errorf("forward %q: %s:%d didn't answer HTTP within %s", f.ID, f.RemoteHost, f.RemotePort, previewTimeout)
+	}
+
+	return openutil.Open(url)
+}
+
+// waitForHTTP polls url until it gets any HTTP response or timeout
+// elapses, returning whether it ever got one.
+func waitForHTTP(url string, timeout time.Duration) bool {
+	client := &http.Client{Timeout: previewPollInterval}
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) {
+		resp, err := client.Get(url)
+		if err == nil {
+			resp.Body.Close()
+			return true
+		}
+		time.Sleep(previewPollInterval)
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+// Package portscan checks whether ports on a remote host are open and
+// tries to grab the service banner of whatever is listening.
+package portscan
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"time"
+)
+
+// Result is the outcome of probing one port.
+type Result struct {
+	Port   int
+	Open   bool
+	Banner string
+}
+
+const (
+	dialTimeout = 2 * time.Second
+	readTimeout = 1 * time.Second
+)
+
+// Probe dials host:port and, if it connects, reads whatever the service
+// sends first as a banner.
+func Probe(host string, port int) Result {
+	addr := fmt.Sprintf("%s:%d", host, port)
+	conn, err := net.DialTimeout("tcp", addr, dialTimeout)
+	if err != nil {
+		return Result{Port: port, Open: false}
+	}
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(readTimeout))
+	banner, _ := bufio.NewReader(conn).ReadString('\n')
+
+	return Result{Port: port, Open: true, Banner: banner}
+}
+
+// ProbeRange probes every port in [start, end] on host concurrently and
+// returns only the ones that are open, ordered by port.
+func ProbeRange(host string, start, end int) []Result {
+	results := make(chan Result, end-start+1)
+	for port := start; port <= end; port++ {
+		port := port
+		go func() { results <- Probe(host, port) }()
+	}
+
+	open := make([]Result, 0)
+	for i := start; i <= end; i++ {
+		if r := <-results; r.Open {
+			open = append(open, r)
+		}
+	}
+
+	for i := 1; i < len(open); i++ {
+		for j := i; j > 0 && open[j-1].Port > open[j].Port; j-- {
+			open[j-1], open[j] = open[j], open[j-1]
+		}
+	}
+	return open
+}
@@ -0,0 +1,127 @@
+// Package prefetch warms a directory-metadata cache in the background
+// during idle bandwidth, so opening a remote directory the user is
+// likely to browse next feels instant.
+package prefetch
+
+import (
+	"sync"
+	"time"
+)
+
+// Fetcher retrieves the metadata worth caching for a remote directory.
+type Fetcher func(dir string) (interface{}, error)
+
+// Prefetcher queues directories to warm and fetches them one at a time
+// whenever the session has been idle for at least idleAfter.
+type Prefetcher struct {
+	fetch     Fetcher
+	idleAfter time.Duration
+
+	mu       sync.Mutex
+	queue    []string
+	cache    map[string]interface{}
+	lastBusy time.Time
+	stop     chan struct{}
+}
+
+// New returns a Prefetcher that considers the session idle once
+// idleAfter has passed since the last call to Touch.
+func New(fetch Fetcher, idleAfter time.Duration) *Prefetcher {
+	return &Prefetcher{
+		fetch:     fetch,
+		idleAfter: idleAfter,
+		cache:     make(map[string]interface{}),
+		lastBusy:  time.Now(),
+	}
+}
+
+// Touch marks the session as active, deferring any idle prefetching.
+func (p *Prefetcher) Touch() {
+	p.mu.Lock()
+	p.lastBusy = time.Now()
+	p.mu.Unlock()
+}
+
+// Queue adds a directory to prefetch the next time the session is idle.
+func (p *Prefetcher) Queue(dir string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, cached := p.cache[dir]; cached {
+		return
+	}
+	for _, d := range p.queue {
+		if d == dir {
+			return
+		}
+	}
+	p.queue = append(p.queue, dir)
+}
+
+// Start begins the background loop that drains the queue during idle
+// periods, checking every checkInterval.
+func (p *Prefetcher) Start(checkInterval time.Duration) {
+	p.mu.Lock()
+	if p.stop != nil {
+		p.mu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	p.stop = stop
+	p.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				p.drainOne()
+			}
+		}
+	}()
+}
+
+func (p *Prefetcher) drainOne() {
+	p.mu.Lock()
+	idle := time.Since(p.lastBusy) >= p.idleAfter
+	var dir string
+	if idle && len(p.queue) > 0 {
+		dir, p.queue = p.queue[0], p.queue[1:]
+	}
+	p.mu.Unlock()
+
+	if dir == "" {
+		return
+	}
+
+	data, err := p.fetch(dir)
+	if err != nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.cache[dir] = data
+	p.mu.Unlock()
+}
+
+// Stop halts the background prefetch loop.
+func (p *Prefetcher) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stop == nil {
+		return
+	}
+	close(p.stop)
+	p.stop = nil
+}
+
+// Get returns a directory's prefetched metadata, if it has been warmed.
+func (p *Prefetcher) Get(dir string) (interface{}, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	data, ok := p.cache[dir]
+	return data, ok
+}
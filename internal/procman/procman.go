@@ -0,0 +1,117 @@
+// Package procman lists processes on a connected host and sends
+// signals to them, giving the UI a task-manager view for any session
+// without needing a remote agent installed.
+package procman
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Process is a single remote process, as reported by ps.
+type Process struct {
+	PID        int
+	PPID       int
+	User       string
+	CPUPercent float64
+	MemPercent float64
+	Command    string
+}
+
+// Runner executes a single command on a connected session and returns
+// its combined output, satisfied by *sshsession.Session.
+type Runner interface {
+	Run(command string) (string, error)
+}
+
+// psCommand uses a fixed column order and no header line so parsing
+// doesn't depend on the remote ps's locale or column-width quirks.
+const psCommand = `ps -eo pid,ppid,user,%cpu,%mem,comm --no-headers`
+
+// List runs ps on r and parses its output into one Process per row.
+// A row ps prints that doesn't parse (rare, but BusyBox's ps varies by
+// build) is skipped rather than failing the whole list.
+func List(r Runner) ([]Process, error) {
+	out, err := r.Run(psCommand)
+	if err != nil {
+		return nil, fmt.Errorf("list processes: %w", err)
+	}
+
+	var procs []Process
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		p, ok := parseLine(line)
+		if !ok {
+			continue
+		}
+		procs = append(procs, p)
+	}
+	return procs, nil
+}
+
+func parseLine(line string) (Process, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 6 {
+		return Process{}, false
+	}
+
+	pid, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return Process{}, false
+	}
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return Process{}, false
+	}
+	cpu, err := strconv.ParseFloat(fields[3], 64)
+	if err != nil {
+		return Process{}, false
+	}
+	mem, err := strconv.ParseFloat(fields[4], 64)
+	if err != nil {
+		return Process{}, false
+	}
+
+	return Process{
+		PID:        pid,
+		PPID:       ppid,
+		User:       fields[2],
+		CPUPercent: cpu,
+		MemPercent: mem,
+		Command:    strings.Join(fields[5:], " "),
+	}, true
+}
+
+// SortByCPU sorts procs by CPUPercent, highest first.
+func SortByCPU(procs []Process) {
+	sort.Slice(procs, func(i, j int) bool { return procs[i].CPUPercent > procs[j].CPUPercent })
+}
+
+// SortByMemory sorts procs by MemPercent, highest first.
+func SortByMemory(procs []Process) {
+	sort.Slice(procs, func(i, j int) bool { return procs[i].MemPercent > procs[j].MemPercent })
+}
+
+// signalName matches bare POSIX signal names/numbers (e.g. "TERM",
+// "SIGKILL", "9"), rejecting anything that could break out of the kill
+// command it's interpolated into.
+var signalName = regexp.MustCompile(`^(SIG)?[A-Z0-9]+$`)
+
+// SendSignal sends signal (e.g. "TERM", "KILL", "HUP", or a bare
+// number) to pid on r.
+func SendSignal(r Runner, pid int, signal string) error {
+	if !signalName.MatchString(signal) {
+		return fmt.Errorf("invalid signal %q", signal)
+	}
+
+	if _, err := r.Run(fmt.Sprintf("kill -%s %d", signal, pid)); err != nil {
+		return fmt.Errorf("send %s to pid %d: %w", signal, pid, err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Activity tracks how recently and how often a profile has been
+// connected to, plus whether the user pinned it as a favorite, kept
+// separate from the profile's own YAML file since it changes on every
+// connection rather than when the user edits the profile.
+type Activity struct {
+	LastConnected time.Time `json:"last_connected,omitempty"`
+	ConnectCount  int       `json:"connect_count,omitempty"`
+	Favorite      bool      `json:"favorite,omitempty"`
+}
+
+const activityPath = "./configs/.activity.json"
+
+// ActivityStore holds every profile's Activity, keyed by profile name.
+type ActivityStore struct {
+	mu       sync.Mutex
+	activity map[string]*Activity
+}
+
+// LoadActivityStore reads the activity store from disk, returning an
+// empty store if it doesn't exist yet.
+func LoadActivityStore() (*ActivityStore, error) {
+	data, err := os.ReadFile(activityPath)
+	if os.IsNotExist(err) {
+		return NewEmptyActivityStore(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read activity store: %w", err)
+	}
+
+	activity := make(map[string]*Activity)
+	if err := json.Unmarshal(data, &activity); err != nil {
+		return nil, fmt.Errorf("parse activity store: %w", err)
+	}
+	return &ActivityStore{activity: activity}, nil
+}
+
+// NewEmptyActivityStore returns a store with no activity recorded.
+func NewEmptyActivityStore() *ActivityStore {
+	return &ActivityStore{activity: make(map[string]*Activity)}
+}
+
+func (s *ActivityStore) save() error {
+	data, err := json.MarshalIndent(s.activity, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode activity store: %w", err)
+	}
+	return os.WriteFile(activityPath, data, 0o644)
+}
+
+// RecordConnect bumps name's LastConnected to now and increments its
+// ConnectCount.
+func (s *ActivityStore) RecordConnect(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.activity[name]
+	if !ok {
+		a = &Activity{}
+		s.activity[name] = a
+	}
+	a.LastConnected = time.Now()
+	a.ConnectCount++
+	return s.save()
+}
+
+// SetFavorite pins or unpins name.
+func (s *ActivityStore) SetFavorite(name string, favorite bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.activity[name]
+	if !ok {
+		a = &Activity{}
+		s.activity[name] = a
+	}
+	a.Favorite = favorite
+	return s.save()
+}
+
+// Get returns name's activity, or a zero Activity if none is recorded.
+func (s *ActivityStore) Get(name string) Activity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a, ok := s.activity[name]; ok {
+		return *a
+	}
+	return Activity{}
+}
+
+// RecentNames returns up to limit profile names with a recorded
+// LastConnected, most recent first.
+func (s *ActivityStore) RecentNames(limit int) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	type entry struct {
+		name string
+		when time.Time
+	}
+	entries := make([]entry, 0, len(s.activity))
+	for name, a := range s.activity {
+		if !a.LastConnected.IsZero() {
+			entries = append(entries, entry{name, a.LastConnected})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].when.After(entries[j].when) })
+
+	if limit > 0 && limit < len(entries) {
+		entries = entries[:limit]
+	}
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		names[i] = e.name
+	}
+	return names
+}
+
+// FavoriteNames returns every profile name pinned as a favorite.
+func (s *ActivityStore) FavoriteNames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var names []string
+	for name, a := range s.activity {
+		if a.Favorite {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,18 @@
+package profile
+
+import "sshapp/internal/cloud"
+
+// FromCloudVM builds a Profile from a discovered cloud VM. username and
+// sshKeyPath come from the caller since cloud inventories don't know
+// how the user authenticates to their own instances.
+func FromCloudVM(vm cloud.VM, username, sshKeyPath string) Profile {
+	return Profile{
+		Name:       vm.Provider + "-" + vm.Name,
+		Host:       vm.Host,
+		Port:       22,
+		Username:   username,
+		SSHKeyPath: sshKeyPath,
+		Group:      vm.Provider,
+		Tags:       []string{vm.Provider, vm.Zone},
+	}
+}
@@ -0,0 +1,118 @@
+package profile
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"sshapp/internal/vault"
+)
+
+// Diagnostics is the structured result of TestProfile, suitable for
+// showing the user exactly what's wrong with a profile before they save
+// it, rather than a single pass/fail bit.
+type Diagnostics struct {
+	FieldErrors  []string // validation problems that never touched the network
+	HostResolves bool
+	KeyParses    bool // true if SSHKeyPath is unset, or set and parses
+	Connected    bool
+	Whoami       string
+	LatencyMs    int64
+	Err          string // set if the connect, session or whoami step fails; empty if FieldErrors stopped the test first
+}
+
+// OK reports whether every check passed.
+func (d Diagnostics) OK() bool {
+	return len(d.FieldErrors) == 0 && d.HostResolves && d.KeyParses && d.Connected
+}
+
+const testConnectTimeout = 5 * time.Second
+
+// TestProfile validates p's fields, checks that its host resolves and
+// its key (if any) parses, then performs a short connect-and-whoami
+// check, returning structured diagnostics rather than a single error so
+// the UI can point at exactly what's wrong. v is the unlocked master
+// password vault, if any; it's only needed when p.VaultRef is set.
+func TestProfile(p Profile, v *vault.Vault) Diagnostics {
+	var d Diagnostics
+
+	if err := validate(p); err != nil {
+		d.FieldErrors = append(d.FieldErrors, err.Error())
+	}
+
+	if _, err := net.LookupHost(p.Host); err != nil {
+		d.FieldErrors = append(d.FieldErrors, fmt.Sprintf("host %q does not resolve: %v", p.Host, err))
+	} else {
+		d.HostResolves = true
+	}
+
+	auth, err := AuthMethod(p, v)
+	if err != nil {
+		d.FieldErrors = append(d.FieldErrors, err.Error())
+	}
+	d.KeyParses = err == nil || p.SSHKeyPath == ""
+
+	if len(d.FieldErrors) > 0 {
+		return d
+	}
+
+	config := &ssh.ClientConfig{
+		User:            p.Username,
+		Auth:            []ssh.AuthMethod{auth},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         testConnectTimeout,
+	}
+
+	start := time.Now()
+	client, err := ssh.Dial("tcp", fmt.Sprintf("%s:%d", p.Host, p.Port), config)
+	if err != nil {
+		d.Err = err.Error()
+		return d
+	}
+	defer client.Close()
+	d.Connected = true
+	d.LatencyMs = time.Since(start).Milliseconds()
+
+	sess, err := client.NewSession()
+	if err != nil {
+		d.Err = fmt.Errorf("open session: %w", err).Error()
+		return d
+	}
+	defer sess.Close()
+
+	out, err := sess.Output("whoami")
+	if err != nil {
+		d.Err = fmt.Errorf("run whoami: %w", err).Error()
+		return d
+	}
+	d.Whoami = strings.TrimSpace(string(out))
+	return d
+}
+
+// AuthMethod builds an ssh.AuthMethod from p's key or password, parsing
+// the key (if any) up front so a malformed key fails fast with a clear
+// error instead of an opaque handshake failure. Exported so Connect (in
+// package main) can build the same ssh.ClientConfig TestProfile does.
+func AuthMethod(p Profile, v *vault.Vault) (ssh.AuthMethod, error) {
+	if p.SSHKeyPath == "" {
+		password, err := ResolvePassword(&p, v)
+		if err != nil {
+			return nil, fmt.Errorf("resolve password: %w", err)
+		}
+		return ssh.Password(password), nil
+	}
+
+	data, err := os.ReadFile(p.SSHKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("read key %q: %w", p.SSHKeyPath, err)
+	}
+	signer, err := ssh.ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse key %q: %w", p.SSHKeyPath, err)
+	}
+	return ssh.PublicKeys(signer), nil
+}
@@ -0,0 +1,524 @@
+// Package profile loads and manages SSH connection profiles stored as YAML
+// files under the configs directory (one profile per file, see README).
+package profile
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"sshapp/internal/keychain"
+	"sshapp/internal/vault"
+)
+
+// keychainService namespaces this app's entries in the OS keychain so
+// they don't collide with other tools' credentials.
+const keychainService = "sshapp"
+
+// Profile describes a single SSH connection target.
+type Profile struct {
+	Name       string `yaml:"name"`
+	Host       string `yaml:"host"`
+	Port       int    `yaml:"port"`
+	Username   string `yaml:"username"`
+	SSHKeyPath string `yaml:"ssh_key_path"`
+	Password   string `yaml:"password,omitempty"`
+
+	// KeychainRef is the OS keychain account holding this profile's
+	// password, set once MigrateToKeychain moves it out of plaintext
+	// YAML. When set, Password is cleared and ResolvePassword reads
+	// from the keychain instead.
+	KeychainRef string `yaml:"keychain_ref,omitempty"`
+
+	// VaultRef is the entry in the master-password vault (see
+	// internal/vault) holding this profile's password, for users
+	// without OS keychain access. Mutually exclusive with KeychainRef
+	// in practice, though nothing enforces that.
+	VaultRef string `yaml:"vault_ref,omitempty"`
+
+	// Group organizes profiles into folders in the UI, e.g. "prod",
+	// "staging/customer-a". Empty means ungrouped.
+	Group string   `yaml:"group,omitempty"`
+	Tags  []string `yaml:"tags,omitempty"`
+
+	// Order controls display order within a group; lower sorts first.
+	// Set by Store.Reorder, otherwise left at its zero value.
+	Order int `yaml:"order,omitempty"`
+
+	// Terminal settings, applied when a PTY is requested for this
+	// profile (see sshsession.PTYOptions); each defaults to
+	// sshsession.DefaultPTYOptions when left empty/zero.
+	TermType        string `yaml:"term_type,omitempty"`
+	Encoding        string `yaml:"encoding,omitempty"`
+	ScrollbackLines int    `yaml:"scrollback_lines,omitempty"`
+	Locale          string `yaml:"locale,omitempty"`
+
+	// Shell is the login shell commands should run through (e.g.
+	// "bash", "zsh", "fish", "sh"), for hosts whose account default
+	// shell doesn't behave the way this app's scripts expect. Empty
+	// uses whatever the SSH server execs by default.
+	Shell string `yaml:"shell,omitempty"`
+
+	// Environment is exported before every command run against this
+	// profile, e.g. for a host that needs PATH or a tool-specific
+	// variable set that isn't already in the account's shell profile.
+	Environment map[string]string `yaml:"environment,omitempty"`
+
+	// path is the file this profile was loaded from, kept so Store can
+	// save it back without the caller needing to know the layout.
+	path string `yaml:"-"`
+}
+
+// Store holds all known profiles, keyed by name, loaded from a directory
+// of YAML files.
+type Store struct {
+	dir string
+
+	mu       sync.RWMutex
+	profiles map[string]*Profile
+}
+
+// NewStore loads every *.yaml file in dir into a Store.
+func NewStore(dir string) (*Store, error) {
+	s := NewEmptyStore(dir)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return s, fmt.Errorf("read configs dir %q: %w", dir, err)
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".yaml") {
+			continue
+		}
+		p, err := loadProfile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return s, fmt.Errorf("load profile %q: %w", e.Name(), err)
+		}
+		s.profiles[p.Name] = p
+	}
+
+	return s, nil
+}
+
+// NewEmptyStore returns a Store with no profiles loaded, e.g. for use when
+// the configs directory does not exist yet.
+func NewEmptyStore(dir string) *Store {
+	return &Store{dir: dir, profiles: make(map[string]*Profile)}
+}
+
+func loadProfile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p Profile
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	p.path = path
+	return &p, nil
+}
+
+// List returns all profiles, sorted by name.
+func (s *Store) List() []*Profile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]*Profile, 0, len(s.profiles))
+	for _, p := range s.profiles {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Get returns the profile with the given name, or nil if it does not exist.
+func (s *Store) Get(name string) *Profile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.profiles[name]
+}
+
+// ListByGroup returns every profile in group, sorted by Order then name.
+func (s *Store) ListByGroup(group string) []*Profile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]*Profile, 0)
+	for _, p := range s.profiles {
+		if p.Group == group {
+			out = append(out, p)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Order != out[j].Order {
+			return out[i].Order < out[j].Order
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// ResolvePassword returns p's password: from the OS keychain if it's
+// been migrated there, from v if it's been migrated to the vault (v
+// may be nil if the vault isn't unlocked, which is an error only if p
+// actually needs it), or its plaintext YAML field otherwise.
+func ResolvePassword(p *Profile, v *vault.Vault) (string, error) {
+	if p.KeychainRef != "" {
+		return keychain.Get(keychainService, p.KeychainRef)
+	}
+	if p.VaultRef != "" {
+		if v == nil {
+			return "", fmt.Errorf("profile %q's password is in the vault, but the vault isn't unlocked", p.Name)
+		}
+		value, ok := v.Get(p.VaultRef)
+		if !ok {
+			return "", fmt.Errorf("vault has no entry %q", p.VaultRef)
+		}
+		return value, nil
+	}
+	return p.Password, nil
+}
+
+// MigrateToVault moves name's plaintext password into v, clearing it
+// from the YAML file on disk. It's a no-op if the profile has no
+// plaintext password or has already been migrated (to either the vault
+// or the keychain).
+func (s *Store) MigrateToVault(name string, v *vault.Vault) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.profiles[name]
+	if !ok {
+		return fmt.Errorf("profile %q not found", name)
+	}
+	if p.Password == "" || p.VaultRef != "" || p.KeychainRef != "" {
+		return nil
+	}
+
+	if err := v.Set(name, p.Password); err != nil {
+		return fmt.Errorf("migrate %q to vault: %w", name, err)
+	}
+
+	p.VaultRef = name
+	p.Password = ""
+	return writeProfile(p)
+}
+
+// MigrateToKeychain moves name's plaintext password into the OS
+// keychain, clearing it from the YAML file on disk. It's a no-op if the
+// profile has no plaintext password or has already been migrated.
+func (s *Store) MigrateToKeychain(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.profiles[name]
+	if !ok {
+		return fmt.Errorf("profile %q not found", name)
+	}
+	if p.Password == "" || p.KeychainRef != "" {
+		return nil
+	}
+
+	if err := keychain.Set(keychainService, name, p.Password); err != nil {
+		return fmt.Errorf("migrate %q to keychain: %w", name, err)
+	}
+
+	p.KeychainRef = name
+	p.Password = ""
+	return writeProfile(p)
+}
+
+// Reorder sets each named profile's Order to its index in names and
+// persists the change, so the UI's drag-to-reorder sticks across
+// restarts. Every name must already exist.
+func (s *Store) Reorder(names []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, name := range names {
+		if _, ok := s.profiles[name]; !ok {
+			return fmt.Errorf("profile %q not found", name)
+		}
+	}
+
+	for i, name := range names {
+		p := s.profiles[name]
+		p.Order = i
+		if err := writeProfile(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reload re-reads every *.yaml file in the Store's directory, adding
+// profiles that appeared, dropping ones that disappeared, and replacing
+// ones that changed, so edits made outside the app (e.g. a dotfiles sync
+// writing new profile YAML directly) are picked up. It reports whether
+// anything actually changed, so callers only polling for a reason to
+// notify the UI can skip a no-op event.
+func (s *Store) Reload() (changed bool, err error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return false, fmt.Errorf("read configs dir %q: %w", s.dir, err)
+	}
+
+	fresh := make(map[string]*Profile, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".yaml") {
+			continue
+		}
+		p, err := loadProfile(filepath.Join(s.dir, e.Name()))
+		if err != nil {
+			return false, fmt.Errorf("load profile %q: %w", e.Name(), err)
+		}
+		fresh[p.Name] = p
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(fresh) != len(s.profiles) {
+		changed = true
+	} else {
+		for name, p := range fresh {
+			existing, ok := s.profiles[name]
+			if !ok || !reflect.DeepEqual(*existing, *p) {
+				changed = true
+				break
+			}
+		}
+	}
+
+	s.profiles = fresh
+	return changed, nil
+}
+
+// MatchTemplate finds a host-pattern profile whose Host (e.g.
+// "*.internal.corp") matches typedHost, mirroring (a useful subset of)
+// OpenSSH's `Host` pattern blocks: a single glob pattern per profile,
+// matched with the same "*"/"?" semantics as path.Match rather than
+// OpenSSH's full negation/multi-pattern grammar. It returns a concrete
+// profile for typedHost with the template's user/key/settings applied,
+// for the quick-connect flow to use without the user having saved a
+// profile for that exact host. ok is false if no template matches.
+func (s *Store) MatchTemplate(typedHost string) (resolved Profile, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, p := range s.profiles {
+		if !strings.ContainsAny(p.Host, "*?") {
+			continue
+		}
+		if matched, _ := path.Match(p.Host, typedHost); matched {
+			resolved = *p
+			resolved.Name = typedHost
+			resolved.Host = typedHost
+			resolved.path = ""
+			resolved.Order = 0
+			return resolved, true
+		}
+	}
+	return Profile{}, false
+}
+
+// Duplicate clones name's profile as newName (e.g. "same bastion,
+// different host"), applying any non-zero field set in overrides on top
+// of the clone before saving. The clone never inherits the source's
+// KeychainRef/VaultRef — those point at a secret tied to the source
+// profile's name, so deleting the source would silently break the
+// clone's password too; set overrides.Password (or migrate the clone
+// separately) instead.
+func (s *Store) Duplicate(name, newName string, overrides Profile) error {
+	s.mu.RLock()
+	src, ok := s.profiles[name]
+	s.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("profile %q not found", name)
+	}
+
+	clone := *src
+	clone.Name = newName
+	clone.path = ""
+	clone.Order = 0
+	clone.KeychainRef = ""
+	clone.VaultRef = ""
+	applyOverrides(&clone, overrides)
+
+	return s.Create(clone)
+}
+
+func applyOverrides(p *Profile, o Profile) {
+	if o.Host != "" {
+		p.Host = o.Host
+	}
+	if o.Port != 0 {
+		p.Port = o.Port
+	}
+	if o.Username != "" {
+		p.Username = o.Username
+	}
+	if o.SSHKeyPath != "" {
+		p.SSHKeyPath = o.SSHKeyPath
+	}
+	if o.Password != "" {
+		p.Password = o.Password
+	}
+	if o.Group != "" {
+		p.Group = o.Group
+	}
+	if len(o.Tags) > 0 {
+		p.Tags = o.Tags
+	}
+	if o.TermType != "" {
+		p.TermType = o.TermType
+	}
+	if o.Encoding != "" {
+		p.Encoding = o.Encoding
+	}
+	if o.ScrollbackLines != 0 {
+		p.ScrollbackLines = o.ScrollbackLines
+	}
+	if o.Locale != "" {
+		p.Locale = o.Locale
+	}
+	if o.Shell != "" {
+		p.Shell = o.Shell
+	}
+	if len(o.Environment) > 0 {
+		p.Environment = o.Environment
+	}
+}
+
+// ValidateName reports whether name is safe to use as a profile's
+// backing filename, rejecting anything that could escape the profiles
+// directory when joined onto it. Exported so callers that build a
+// Profile from untrusted input (e.g. internal/bundle importing a
+// shared zip) can reject a bad name before it ever reaches Create.
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if strings.ContainsAny(name, "/\\") || strings.Contains(name, "..") {
+		return fmt.Errorf("name %q must not contain path separators or \"..\"", name)
+	}
+	return nil
+}
+
+// validate checks the fields every profile needs to be usable, without
+// touching the network (that's TestProfile's job).
+func validate(p Profile) error {
+	if err := ValidateName(p.Name); err != nil {
+		return err
+	}
+	if p.Host == "" {
+		return fmt.Errorf("host is required")
+	}
+	if p.Port <= 0 || p.Port > 65535 {
+		return fmt.Errorf("port %d out of range", p.Port)
+	}
+	if p.Username == "" {
+		return fmt.Errorf("username is required")
+	}
+	return nil
+}
+
+// Create validates p and writes it as a new YAML file in the Store's
+// directory, returning an error if a profile with that name already
+// exists.
+func (s *Store) Create(p Profile) error {
+	if err := validate(p); err != nil {
+		return fmt.Errorf("invalid profile: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.profiles[p.Name]; exists {
+		return fmt.Errorf("profile %q already exists", p.Name)
+	}
+
+	p.path = filepath.Join(s.dir, p.Name+".yaml")
+	if err := writeProfile(&p); err != nil {
+		return err
+	}
+	s.profiles[p.Name] = &p
+	return nil
+}
+
+// Update validates p and overwrites the existing profile named name,
+// renaming its backing file if p.Name differs from name.
+func (s *Store) Update(name string, p Profile) error {
+	if err := validate(p); err != nil {
+		return fmt.Errorf("invalid profile: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.profiles[name]
+	if !ok {
+		return fmt.Errorf("profile %q not found", name)
+	}
+
+	if p.Name != name {
+		if _, exists := s.profiles[p.Name]; exists {
+			return fmt.Errorf("profile %q already exists", p.Name)
+		}
+		newPath := filepath.Join(s.dir, p.Name+".yaml")
+		if err := os.Rename(existing.path, newPath); err != nil {
+			return fmt.Errorf("rename profile %q: %w", name, err)
+		}
+		delete(s.profiles, name)
+		p.path = newPath
+	} else {
+		p.path = existing.path
+	}
+
+	if err := writeProfile(&p); err != nil {
+		return err
+	}
+	s.profiles[p.Name] = &p
+	return nil
+}
+
+// Delete removes name's profile and its backing YAML file.
+func (s *Store) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.profiles[name]
+	if !ok {
+		return fmt.Errorf("profile %q not found", name)
+	}
+	if p.KeychainRef != "" {
+		if err := keychain.Delete(keychainService, p.KeychainRef); err != nil {
+			return fmt.Errorf("delete profile %q: remove keychain entry: %w", name, err)
+		}
+	}
+	if err := os.Remove(p.path); err != nil {
+		return fmt.Errorf("delete profile %q: %w", name, err)
+	}
+	delete(s.profiles, name)
+	return nil
+}
+
+func writeProfile(p *Profile) error {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("encode profile %q: %w", p.Name, err)
+	}
+	if err := os.WriteFile(p.path, data, 0o600); err != nil {
+		return fmt.Errorf("write profile %q: %w", p.Name, err)
+	}
+	return nil
+}
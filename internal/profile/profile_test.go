@@ -0,0 +1,47 @@
+package profile
+
+import "testing"
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "prod-db", wantErr: false},
+		{name: "prod.db-01", wantErr: false},
+		{name: "", wantErr: true},
+		{name: "../escape", wantErr: true},
+		{name: "a/b", wantErr: true},
+		{name: `a\b`, wantErr: true},
+		{name: "..", wantErr: true},
+		{name: "weird..name", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateName(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateName(%q) = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	base := Profile{Name: "prod", Host: "example.com", Port: 22, Username: "root"}
+
+	if err := validate(base); err != nil {
+		t.Errorf("validate(%+v): unexpected error %v", base, err)
+	}
+
+	cases := []Profile{
+		{Name: "../escape", Host: "example.com", Port: 22, Username: "root"},
+		{Name: "prod", Host: "", Port: 22, Username: "root"},
+		{Name: "prod", Host: "example.com", Port: 0, Username: "root"},
+		{Name: "prod", Host: "example.com", Port: 70000, Username: "root"},
+		{Name: "prod", Host: "example.com", Port: 22, Username: ""},
+	}
+	for _, p := range cases {
+		if err := validate(p); err == nil {
+			t.Errorf("validate(%+v): want error, got nil", p)
+		}
+	}
+}
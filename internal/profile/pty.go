@@ -0,0 +1,19 @@
+package profile
+
+import "sshapp/internal/sshsession"
+
+// PTYOptions converts p's terminal settings into the options a PTY
+// request should use, falling back to sshsession.DefaultPTYOptions for
+// anything p doesn't set.
+func (p *Profile) PTYOptions() sshsession.PTYOptions {
+	locale := p.Locale
+	if locale == "" && p.Encoding != "" {
+		locale = "en_US." + p.Encoding
+	}
+	return sshsession.PTYOptions{
+		TermType:        p.TermType,
+		Encoding:        p.Encoding,
+		ScrollbackLines: p.ScrollbackLines,
+		Locale:          locale,
+	}
+}
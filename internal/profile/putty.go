@@ -0,0 +1,313 @@
+package profile
+
+import (
+	"bufio"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ImportPuTTYSessions reads a PuTTY sessions registry export (produced
+// on Windows with `reg export HKCU\Software\SimonTatham\PuTTY\Sessions
+// sessions.reg`) and converts every saved session into a Profile.
+// keyDir is where converted private keys are written; if empty, keys
+// land next to their source .ppk file. A session whose key can't be
+// converted (encrypted, or not an unencrypted ppk v2 RSA key) is still
+// imported, but comes back without SSHKeyPath set and with the reason
+// appended to warnings, so one bad key doesn't block the rest of the
+// import.
+func ImportPuTTYSessions(regPath, keyDir string) (profiles []Profile, warnings []string, err error) {
+	f, err := os.Open(regPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open %q: %w", regPath, err)
+	}
+	defer f.Close()
+
+	sections, err := parseRegSessions(f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse %q: %w", regPath, err)
+	}
+
+	for _, sec := range sections {
+		p := Profile{
+			Name:     sec.name,
+			Host:     sec.fields["HostName"],
+			Username: sec.fields["UserName"],
+			Port:     22,
+		}
+		if port, err := strconv.Atoi(sec.fields["PortNumber"]); err == nil && port > 0 {
+			p.Port = port
+		}
+
+		// PuTTY confusingly calls the private key field "PublicKeyFile"
+		// in the registry; it's the path to the .ppk file used to
+		// authenticate, not a public key.
+		if ppkPath := sec.fields["PublicKeyFile"]; ppkPath != "" {
+			keyPath, convErr := convertPPKFile(ppkPath, keyDir)
+			if convErr != nil {
+				warnings = append(warnings, fmt.Sprintf("session %q: %v", p.Name, convErr))
+			} else {
+				p.SSHKeyPath = keyPath
+			}
+		}
+
+		profiles = append(profiles, p)
+	}
+	return profiles, warnings, nil
+}
+
+type regSession struct {
+	name   string
+	fields map[string]string
+}
+
+var (
+	regSessionHeader = regexp.MustCompile(`\\Sessions\\([^\]]+)\]\s*$`)
+	regKeyValue      = regexp.MustCompile(`^"([^"]+)"=(.*)$`)
+)
+
+// parseRegSessions scans a Windows .reg export for
+// [...\Sessions\<name>] blocks and the "Key"="value" / "Key"=dword:...
+// lines under each.
+func parseRegSessions(r io.Reader) ([]regSession, error) {
+	var sessions []regSession
+	var current *regSession
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			current = nil
+			if m := regSessionHeader.FindStringSubmatch(line); m != nil {
+				name, err := url.PathUnescape(m[1])
+				if err != nil {
+					name = m[1]
+				}
+				sessions = append(sessions, regSession{name: name, fields: make(map[string]string)})
+				current = &sessions[len(sessions)-1]
+			}
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+		m := regKeyValue.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		current.fields[m[1]] = parseRegValue(m[2])
+	}
+	return sessions, scanner.Err()
+}
+
+// parseRegValue decodes a .reg value after the "=": either a quoted,
+// backslash-escaped string, or a dword:hhhhhhhh.
+func parseRegValue(raw string) string {
+	if strings.HasPrefix(raw, "dword:") {
+		n, err := strconv.ParseUint(strings.TrimPrefix(raw, "dword:"), 16, 32)
+		if err != nil {
+			return ""
+		}
+		return strconv.FormatUint(n, 10)
+	}
+
+	raw = strings.TrimPrefix(raw, "\"")
+	raw = strings.TrimSuffix(raw, "\"")
+	raw = strings.ReplaceAll(raw, `\\`, `\`)
+	raw = strings.ReplaceAll(raw, `\"`, `"`)
+	return raw
+}
+
+// convertPPKFile reads a PuTTY ppk v2 key and writes it out as a PEM
+// file ssh.ParsePrivateKey can read, returning the new file's path.
+func convertPPKFile(ppkPath, keyDir string) (string, error) {
+	data, err := os.ReadFile(ppkPath)
+	if err != nil {
+		return "", fmt.Errorf("read key %q: %w", ppkPath, err)
+	}
+
+	pemBytes, err := ppkToPEM(data)
+	if err != nil {
+		return "", fmt.Errorf("convert key %q: %w", ppkPath, err)
+	}
+
+	if keyDir == "" {
+		keyDir = filepath.Dir(ppkPath)
+	}
+	if err := os.MkdirAll(keyDir, 0o700); err != nil {
+		return "", fmt.Errorf("create key dir %q: %w", keyDir, err)
+	}
+
+	base := strings.TrimSuffix(filepath.Base(ppkPath), filepath.Ext(ppkPath))
+	outPath := filepath.Join(keyDir, base+".pem")
+	if err := os.WriteFile(outPath, pemBytes, 0o600); err != nil {
+		return "", fmt.Errorf("write converted key %q: %w", outPath, err)
+	}
+	return outPath, nil
+}
+
+// ppkToPEM converts an unencrypted PuTTY-User-Key-File-2 RSA key to a
+// PKCS#1 PEM block. Encrypted keys, non-RSA keys, and ppk v3 (PuTTY
+// 0.75+) files aren't supported; the caller should ask the user to
+// re-export the key as OpenSSH from PuTTYgen instead.
+func ppkToPEM(data []byte) ([]byte, error) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "PuTTY-User-Key-File-2:") {
+		return nil, fmt.Errorf("unsupported ppk format (only PuTTY-User-Key-File-2 is supported)")
+	}
+	if algo := strings.TrimSpace(strings.TrimPrefix(lines[0], "PuTTY-User-Key-File-2:")); algo != "ssh-rsa" {
+		return nil, fmt.Errorf("unsupported key algorithm %q (only ssh-rsa is supported)", algo)
+	}
+
+	i := 1
+	readField := func(label string) (string, error) {
+		if i >= len(lines) || !strings.HasPrefix(lines[i], label+":") {
+			return "", fmt.Errorf("expected %q line", label)
+		}
+		v := strings.TrimSpace(strings.TrimPrefix(lines[i], label+":"))
+		i++
+		return v, nil
+	}
+	readBlock := func(label string) (string, error) {
+		countStr, err := readField(label)
+		if err != nil {
+			return "", err
+		}
+		n, err := strconv.Atoi(countStr)
+		if err != nil {
+			return "", fmt.Errorf("bad %s count: %w", label, err)
+		}
+		var sb strings.Builder
+		for j := 0; j < n; j++ {
+			if i >= len(lines) {
+				return "", fmt.Errorf("truncated %s block", label)
+			}
+			sb.WriteString(strings.TrimSpace(lines[i]))
+			i++
+		}
+		return sb.String(), nil
+	}
+
+	encryption, err := readField("Encryption")
+	if err != nil {
+		return nil, err
+	}
+	if encryption != "none" {
+		return nil, fmt.Errorf("encrypted ppk files aren't supported; remove the passphrase in PuTTYgen first")
+	}
+	if _, err := readField("Comment"); err != nil {
+		return nil, err
+	}
+
+	pubB64, err := readBlock("Public-Lines")
+	if err != nil {
+		return nil, err
+	}
+	privB64, err := readBlock("Private-Lines")
+	if err != nil {
+		return nil, err
+	}
+
+	pubBlob, err := base64.StdEncoding.DecodeString(pubB64)
+	if err != nil {
+		return nil, fmt.Errorf("decode public blob: %w", err)
+	}
+	privBlob, err := base64.StdEncoding.DecodeString(privB64)
+	if err != nil {
+		return nil, fmt.Errorf("decode private blob: %w", err)
+	}
+
+	pub := &sshWireReader{buf: pubBlob}
+	if keyType, _ := pub.readString(); keyType != "ssh-rsa" {
+		return nil, fmt.Errorf("unexpected key type %q in public blob", keyType)
+	}
+	e := pub.readMPInt()
+	n := pub.readMPInt()
+	if pub.err != nil {
+		return nil, fmt.Errorf("parse public blob: %w", pub.err)
+	}
+
+	priv := &sshWireReader{buf: privBlob}
+	d := priv.readMPInt()
+	p := priv.readMPInt()
+	q := priv.readMPInt()
+	priv.readMPInt() // iqmp; Go recomputes this itself
+	if priv.err != nil {
+		return nil, fmt.Errorf("parse private blob: %w", priv.err)
+	}
+
+	key := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{N: n, E: int(e.Int64())},
+		D:         d,
+		Primes:    []*big.Int{p, q},
+	}
+	key.Precompute()
+	if err := key.Validate(); err != nil {
+		return nil, fmt.Errorf("converted key failed validation: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), nil
+}
+
+// sshWireReader reads the length-prefixed strings and mpints that make
+// up the public/private blobs inside a ppk file, which use the same
+// wire format as the SSH protocol itself.
+type sshWireReader struct {
+	buf []byte
+	pos int
+	err error
+}
+
+func (r *sshWireReader) readUint32() uint32 {
+	if r.err != nil || r.pos+4 > len(r.buf) {
+		r.err = fmt.Errorf("truncated data")
+		return 0
+	}
+	v := binary.BigEndian.Uint32(r.buf[r.pos:])
+	r.pos += 4
+	return v
+}
+
+func (r *sshWireReader) readString() (string, error) {
+	n := r.readUint32()
+	if r.err != nil {
+		return "", r.err
+	}
+	if r.pos+int(n) > len(r.buf) {
+		r.err = fmt.Errorf("truncated data")
+		return "", r.err
+	}
+	s := string(r.buf[r.pos : r.pos+int(n)])
+	r.pos += int(n)
+	return s, nil
+}
+
+func (r *sshWireReader) readMPInt() *big.Int {
+	n := r.readUint32()
+	if r.err != nil {
+		return new(big.Int)
+	}
+	if r.pos+int(n) > len(r.buf) {
+		r.err = fmt.Errorf("truncated data")
+		return new(big.Int)
+	}
+	v := new(big.Int).SetBytes(r.buf[r.pos : r.pos+int(n)])
+	r.pos += int(n)
+	return v
+}
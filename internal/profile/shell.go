@@ -0,0 +1,8 @@
+package profile
+
+// ShellOptions returns the shell and environment a session for this
+// profile should use, for passing to sshsession.Session.SetShellOptions.
+// App.Connect applies it to every session it opens for a profile.
+func (p *Profile) ShellOptions() (shell string, env map[string]string) {
+	return p.Shell, p.Environment
+}
@@ -0,0 +1,43 @@
+// Package redact scrubs sensitive data (passwords, tokens, secrets) out
+// of commands before they're written to history.
+package redact
+
+import "regexp"
+
+// Rule matches a sensitive pattern in a command and describes how to
+// mask it. Pattern must contain exactly one capturing group around the
+// sensitive value; everything outside the group is left untouched.
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// Mask is substituted for whatever a Rule's capturing group matched.
+const Mask = "********"
+
+// DefaultRules covers the most common ways secrets end up on a command
+// line: explicit --password/-p flags, inline env var assignments named
+// like secrets, and mysql/psql-style attached passwords.
+var DefaultRules = []Rule{
+	{Name: "password-flag", Pattern: regexp.MustCompile(`(?i)(?:--password[= ]|-p)(\S+)`)},
+	{Name: "token-env", Pattern: regexp.MustCompile(`(?i)\b(?:export\s+)?(?:TOKEN|SECRET|API_KEY|PASSWORD)=(\S+)`)},
+	{Name: "mysql-p-flag", Pattern: regexp.MustCompile(`\bmysql\s+.*-p(\S+)`)},
+}
+
+// Apply masks every match of every rule in command, returning the
+// redacted command and whether any rule matched.
+func Apply(command string, rules []Rule) (string, bool) {
+	redacted := false
+	for _, rule := range rules {
+		matches := rule.Pattern.FindAllSubmatchIndex([]byte(command), -1)
+		if len(matches) == 0 {
+			continue
+		}
+		redacted = true
+		for i := len(matches) - 1; i >= 0; i-- {
+			start, end := matches[i][2], matches[i][3]
+			command = command[:start] + Mask + command[end:]
+		}
+	}
+	return command, redacted
+}
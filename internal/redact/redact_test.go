@@ -0,0 +1,66 @@
+package redact
+
+import "testing"
+
+func TestApplyDefaultRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    string
+	}{
+		{
+			name:    "password flag with space",
+			command: "mytool --password hunter2",
+			want:    "mytool --password " + Mask,
+		},
+		{
+			name:    "password flag with equals",
+			command: "mytool --password=hunter2",
+			want:    "mytool --password=" + Mask,
+		},
+		{
+			name:    "short p flag",
+			command: "mytool -phunter2",
+			want:    "mytool -p" + Mask,
+		},
+		{
+			name:    "token env assignment",
+			command: "export TOKEN=abc123 && deploy",
+			want:    "export TOKEN=" + Mask + " && deploy",
+		},
+		{
+			name:    "mysql attached password",
+			command: "mysql -u root -phunter2 mydb",
+			want:    "mysql -u root -p" + Mask + " mydb",
+		},
+		{
+			name:    "no secrets",
+			command: "ls -la /tmp",
+			want:    "ls -la /tmp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, redacted := Apply(tt.command, DefaultRules)
+			if got != tt.want {
+				t.Errorf("Apply(%q) = %q, want %q", tt.command, got, tt.want)
+			}
+			wantRedacted := tt.want != tt.command
+			if redacted != wantRedacted {
+				t.Errorf("Apply(%q) redacted = %v, want %v", tt.command, redacted, wantRedacted)
+			}
+		})
+	}
+}
+
+func TestApplyMultipleMatchesSameRule(t *testing.T) {
+	got, redacted := Apply("export TOKEN=abc && export SECRET=def", DefaultRules)
+	want := "export TOKEN=" + Mask + " && export SECRET=" + Mask
+	if got != want {
+		t.Fatalf("Apply() = %q, want %q", got, want)
+	}
+	if !redacted {
+		t.Fatalf("Apply() redacted = false, want true")
+	}
+}
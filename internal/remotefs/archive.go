@@ -0,0 +1,66 @@
+package remotefs
+
+import (
+	"fmt"
+	"strings"
+
+	"sshapp/internal/sshsession"
+)
+
+// ArchiveFormat is a remote archive format CompressRemote can produce.
+type ArchiveFormat string
+
+const (
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+	ArchiveZip   ArchiveFormat = "zip"
+)
+
+// CompressRemote archives paths into archivePath on sess's host, using
+// tar or zip depending on format, so users can bundle up e.g. a log
+// directory before downloading it.
+func CompressRemote(sess *sshsession.Session, paths []string, archivePath string, format ArchiveFormat) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("compress %q: no paths given", archivePath)
+	}
+
+	var quoted []string
+	for _, p := range paths {
+		quoted = append(quoted, shellQuote(p))
+	}
+
+	var cmd string
+	switch format {
+	case ArchiveZip:
+		cmd = fmt.Sprintf("zip -r %s %s", shellQuote(archivePath), strings.Join(quoted, " "))
+	case ArchiveTarGz:
+		cmd = fmt.Sprintf("tar -czf %s %s", shellQuote(archivePath), strings.Join(quoted, " "))
+	default:
+		return fmt.Errorf("compress %q: unsupported format %q", archivePath, format)
+	}
+
+	if _, err := sess.Run(cmd); err != nil {
+		return fmt.Errorf("compress %q: %w", archivePath, err)
+	}
+	return nil
+}
+
+// ExtractRemote extracts archivePath into destDir on sess's host,
+// detecting tar.gz/tgz vs zip from its extension.
+func ExtractRemote(sess *sshsession.Session, archivePath, destDir string) error {
+	var cmd string
+	switch {
+	case strings.HasSuffix(archivePath, ".zip"):
+		cmd = fmt.Sprintf("mkdir -p %s && unzip -o %s -d %s", shellQuote(destDir), shellQuote(archivePath), shellQuote(destDir))
+	case strings.HasSuffix(archivePath, ".tar.gz"), strings.HasSuffix(archivePath, ".tgz"):
+		cmd = fmt.Sprintf("mkdir -p %s && tar -xzf %s -C %s", shellQuote(destDir), shellQuote(archivePath), shellQuote(destDir))
+	case strings.HasSuffix(archivePath, ".tar"):
+		cmd = fmt.Sprintf("mkdir -p %s && tar -xf %s -C %s", shellQuote(destDir), shellQuote(archivePath), shellQuote(destDir))
+	default:
+		return fmt.Errorf("extract %q: unrecognized archive extension", archivePath)
+	}
+
+	if _, err := sess.Run(cmd); err != nil {
+		return fmt.Errorf("extract %q: %w", archivePath, err)
+	}
+	return nil
+}
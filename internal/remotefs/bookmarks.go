@@ -0,0 +1,138 @@
+package remotefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+const bookmarksDir = "./filebrowser"
+const bookmarksPath = bookmarksDir + "/bookmarks.json"
+
+// profileBookmarks is one profile's bookmarked paths and where the file
+// browser last left off.
+type profileBookmarks struct {
+	Paths   []string `json:"paths"`
+	LastDir string   `json:"last_dir,omitempty"`
+}
+
+// BookmarkStore tracks bookmarked remote paths and the last-visited
+// directory per profile, so the file browser can offer quick jumps and
+// reopen where the user left off.
+type BookmarkStore struct {
+	mu        sync.Mutex
+	bookmarks map[string]*profileBookmarks
+}
+
+// LoadBookmarks reads the bookmark store from disk, returning an empty
+// store if it doesn't exist yet.
+func LoadBookmarks() (*BookmarkStore, error) {
+	data, err := os.ReadFile(bookmarksPath)
+	if os.IsNotExist(err) {
+		return NewEmptyBookmarks(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read bookmarks: %w", err)
+	}
+
+	bookmarks := make(map[string]*profileBookmarks)
+	if err := json.Unmarshal(data, &bookmarks); err != nil {
+		return nil, fmt.Errorf("parse bookmarks: %w", err)
+	}
+	return &BookmarkStore{bookmarks: bookmarks}, nil
+}
+
+// NewEmptyBookmarks returns a bookmark store with no bookmarks.
+func NewEmptyBookmarks() *BookmarkStore {
+	return &BookmarkStore{bookmarks: make(map[string]*profileBookmarks)}
+}
+
+func (b *BookmarkStore) save() error {
+	if err := os.MkdirAll(bookmarksDir, 0o755); err != nil {
+		return fmt.Errorf("create bookmarks dir: %w", err)
+	}
+	data, err := json.MarshalIndent(b.bookmarks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode bookmarks: %w", err)
+	}
+	if err := os.WriteFile(bookmarksPath, data, 0o644); err != nil {
+		return fmt.Errorf("write bookmarks: %w", err)
+	}
+	return nil
+}
+
+func (b *BookmarkStore) entry(profileName string) *profileBookmarks {
+	pb, ok := b.bookmarks[profileName]
+	if !ok {
+		pb = &profileBookmarks{}
+		b.bookmarks[profileName] = pb
+	}
+	return pb
+}
+
+// AddBookmark bookmarks path for profileName.
+func (b *BookmarkStore) AddBookmark(profileName, path string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	pb := b.entry(profileName)
+	for _, p := range pb.Paths {
+		if p == path {
+			return nil
+		}
+	}
+	pb.Paths = append(pb.Paths, path)
+	return b.save()
+}
+
+// RemoveBookmark removes path from profileName's bookmarks.
+func (b *BookmarkStore) RemoveBookmark(profileName, path string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	pb, ok := b.bookmarks[profileName]
+	if !ok {
+		return nil
+	}
+	for i, p := range pb.Paths {
+		if p == path {
+			pb.Paths = append(pb.Paths[:i], pb.Paths[i+1:]...)
+			return b.save()
+		}
+	}
+	return nil
+}
+
+// ListBookmarks returns profileName's bookmarked paths.
+func (b *BookmarkStore) ListBookmarks(profileName string) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	pb, ok := b.bookmarks[profileName]
+	if !ok {
+		return nil
+	}
+	return append([]string(nil), pb.Paths...)
+}
+
+// SetLastDir records dir as the last directory profileName's file browser
+// visited.
+func (b *BookmarkStore) SetLastDir(profileName, dir string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.entry(profileName).LastDir = dir
+	return b.save()
+}
+
+// LastDir returns the last directory profileName's file browser visited,
+// or "" if none is recorded.
+func (b *BookmarkStore) LastDir(profileName string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	pb, ok := b.bookmarks[profileName]
+	if !ok {
+		return ""
+	}
+	return pb.LastDir
+}
@@ -0,0 +1,136 @@
+package remotefs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"sshapp/internal/sshsession"
+)
+
+// BulkOp is one of the operations BulkFileOperation can apply to a batch
+// of selected paths.
+type BulkOp string
+
+const (
+	BulkDelete   BulkOp = "delete"
+	BulkMove     BulkOp = "move"
+	BulkDownload BulkOp = "download"
+	BulkChmod    BulkOp = "chmod"
+)
+
+// BulkOptions carries whichever extra arguments a given BulkOp needs.
+type BulkOptions struct {
+	DestDir   string      // BulkMove: directory moved-to paths land in
+	LocalDir  string      // BulkDownload: local directory downloaded files land in
+	Mode      os.FileMode // BulkChmod
+	Recursive bool        // BulkChmod
+	UseTrash  bool        // BulkDelete
+}
+
+// BulkItemResult is one path's outcome from a BulkFileOperation call.
+type BulkItemResult struct {
+	Path string
+	Err  string // empty on success
+}
+
+// BulkProgress is a point-in-time snapshot of a running bulk operation,
+// meant to be streamed to the frontend as a single consolidated event
+// rather than one event per item.
+type BulkProgress struct {
+	Op      BulkOp
+	Total   int
+	Done    int
+	Results []BulkItemResult
+}
+
+// bulkConcurrency bounds how many paths BulkFileOperation touches at
+// once, so selecting thousands of files doesn't open thousands of
+// simultaneous SFTP/exec requests.
+const bulkConcurrency = 4
+
+// BulkFileOperation runs op over paths with a bounded worker pool,
+// calling onProgress (if non-nil) after every completed item with a
+// consolidated snapshot of every result so far, so the caller needs to
+// listen to only one event stream instead of one per item.
+func BulkFileOperation(sess *sshsession.Session, op BulkOp, paths []string, opts BulkOptions, onProgress func(BulkProgress)) []BulkItemResult {
+	var mu sync.Mutex
+	results := make([]BulkItemResult, 0, len(paths))
+
+	emit := func() {
+		if onProgress == nil {
+			return
+		}
+		mu.Lock()
+		snapshot := append([]BulkItemResult(nil), results...)
+		mu.Unlock()
+		onProgress(BulkProgress{Op: op, Total: len(paths), Done: len(snapshot), Results: snapshot})
+	}
+
+	work := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < bulkConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range work {
+				res := BulkItemResult{Path: p}
+				if err := bulkApply(sess, op, p, opts); err != nil {
+					res.Err = err.Error()
+				}
+
+				mu.Lock()
+				results = append(results, res)
+				mu.Unlock()
+				emit()
+			}
+		}()
+	}
+
+	for _, p := range paths {
+		work <- p
+	}
+	close(work)
+	wg.Wait()
+
+	return results
+}
+
+func bulkApply(sess *sshsession.Session, op BulkOp, p string, opts BulkOptions) error {
+	switch op {
+	case BulkDelete:
+		return DeleteRemoteFile(sess, p, opts.UseTrash)
+	case BulkMove:
+		return MoveRemote(sess, p, path.Join(opts.DestDir, path.Base(p)))
+	case BulkDownload:
+		return bulkDownloadOne(sess, p, opts.LocalDir)
+	case BulkChmod:
+		return SetPermissions(sess, p, opts.Mode, opts.Recursive)
+	default:
+		return fmt.Errorf("bulk operation %q: unknown op", op)
+	}
+}
+
+func bulkDownloadOne(sess *sshsession.Session, remotePath, localDir string) error {
+	r, err := OpenAuto(sess, remotePath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := os.MkdirAll(localDir, 0o755); err != nil {
+		return fmt.Errorf("create %q: %w", localDir, err)
+	}
+
+	f, err := os.Create(filepath.Join(localDir, path.Base(remotePath)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	return err
+}
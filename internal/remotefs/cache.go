@@ -0,0 +1,34 @@
+package remotefs
+
+import (
+	"github.com/pkg/sftp"
+
+	"sshapp/internal/sshsession"
+)
+
+// client returns sess's cached SFTP client, lazily creating one on
+// first use. The cache itself lives on sess (sshsession.Session), so
+// it's torn down automatically when the session closes instead of
+// outliving it.
+func client(sess *sshsession.Session) (*sftp.Client, error) {
+	return sess.GetOrCreateSFTPClient(func() (*sftp.Client, error) {
+		// Tuned so multi-GB file transfers pipeline many outstanding
+		// requests at a large packet size instead of being
+		// latency-bound by waiting for each chunk's ACK before
+		// sending the next.
+		return sftp.NewClient(sess.UnderlyingClient(),
+			sftp.UseConcurrentWrites(true),
+			sftp.UseConcurrentReads(true),
+			sftp.MaxConcurrentRequestsPerFile(64),
+			sftp.MaxPacketUnchecked(1<<18),
+		)
+	})
+}
+
+// invalidate discards sess's cached SFTP client, so the next client()
+// call opens a fresh one. Call this whenever an SFTP operation fails in
+// a way that suggests the client itself (rather than just the one call)
+// is broken.
+func invalidate(sess *sshsession.Session) {
+	sess.InvalidateSFTPClient()
+}
@@ -0,0 +1,83 @@
+package remotefs
+
+import (
+	"fmt"
+
+	"sshapp/internal/sshsession"
+)
+
+// CopyRemote copies src to dst entirely on sess's host, without
+// round-tripping the data through the local machine. It prefers a
+// single `cp -r` exec (cheap for the server, one round-trip) and falls
+// back to an SFTP read/write loop when the remote shell has no cp.
+func CopyRemote(sess *sshsession.Session, src, dst string) error {
+	if _, err := sess.Run(fmt.Sprintf("cp -r %s %s", shellQuote(src), shellQuote(dst))); err == nil {
+		return nil
+	}
+	return sftpCopy(sess, src, dst)
+}
+
+// MoveRemote moves src to dst entirely on sess's host. It prefers a
+// single `mv` exec and falls back to an SFTP rename.
+func MoveRemote(sess *sshsession.Session, src, dst string) error {
+	if _, err := sess.Run(fmt.Sprintf("mv %s %s", shellQuote(src), shellQuote(dst))); err == nil {
+		return nil
+	}
+
+	c, err := client(sess)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Rename(src, dst); err != nil {
+		return fmt.Errorf("move %q to %q: %w", src, dst, err)
+	}
+	return nil
+}
+
+// sftpCopy recursively copies src to dst purely over SFTP, for servers
+// whose shell has no cp available.
+func sftpCopy(sess *sshsession.Session, src, dst string) error {
+	c, err := client(sess)
+	if err != nil {
+		return err
+	}
+
+	info, err := c.Stat(src)
+	if err != nil {
+		return fmt.Errorf("stat %q: %w", src, err)
+	}
+
+	if info.IsDir() {
+		if err := c.MkdirAll(dst); err != nil {
+			return fmt.Errorf("create %q: %w", dst, err)
+		}
+		entries, err := c.ReadDir(src)
+		if err != nil {
+			return fmt.Errorf("list %q: %w", src, err)
+		}
+		for _, e := range entries {
+			if err := sftpCopy(sess, src+"/"+e.Name(), dst+"/"+e.Name()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	r, err := c.Open(src)
+	if err != nil {
+		return fmt.Errorf("open %q: %w", src, err)
+	}
+	defer r.Close()
+
+	w, err := c.Create(dst)
+	if err != nil {
+		return fmt.Errorf("create %q: %w", dst, err)
+	}
+	defer w.Close()
+
+	if _, err := w.ReadFrom(r); err != nil {
+		return fmt.Errorf("copy %q to %q: %w", src, dst, err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package remotefs
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sshapp/internal/sshsession"
+)
+
+// DiskUsageNode is one entry of a `du` tree: a path and how many
+// kilobytes it (and its children, if any were requested) occupy.
+type DiskUsageNode struct {
+	Path     string
+	SizeKB   int64
+	Children []*DiskUsageNode
+}
+
+// GetDiskUsage runs `du` on path up to depth levels deep on sess's host
+// and returns the result as a size tree, so the file browser can show
+// what's filling up disk without the user dropping to a shell.
+func GetDiskUsage(sess *sshsession.Session, path string, depth int) (*DiskUsageNode, error) {
+	if depth < 0 {
+		depth = 0
+	}
+
+	output, err := sess.Run(fmt.Sprintf("du -k -d %d %s 2>/dev/null", depth, shellQuote(path)))
+	if err != nil {
+		return nil, fmt.Errorf("du %q: %w", path, err)
+	}
+
+	nodes := make(map[string]*DiskUsageNode)
+	var order []string
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.SplitN(line, "\t", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		sizeKB, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		p := fields[1]
+		nodes[p] = &DiskUsageNode{Path: p, SizeKB: sizeKB}
+		order = append(order, p)
+	}
+
+	root, ok := nodes[path]
+	if !ok {
+		return nil, fmt.Errorf("du %q: no output for root path", path)
+	}
+
+	// Attach every other node to its closest ancestor already in the
+	// map, so paths `du` doesn't report every intermediate level for
+	// still nest correctly.
+	sort.Strings(order)
+	for _, p := range order {
+		if p == path {
+			continue
+		}
+		parent := closestAncestor(nodes, order, p)
+		if parent != nil {
+			parent.Children = append(parent.Children, nodes[p])
+		}
+	}
+	return root, nil
+}
+
+func closestAncestor(nodes map[string]*DiskUsageNode, order []string, path string) *DiskUsageNode {
+	best := ""
+	for _, candidate := range order {
+		if candidate == path {
+			continue
+		}
+		if strings.HasPrefix(path, candidate+"/") && len(candidate) > len(best) {
+			best = candidate
+		}
+	}
+	if best == "" {
+		return nil
+	}
+	return nodes[best]
+}
+
+// DiskFree reports free/used/total space for every mounted filesystem on
+// sess's host, parsed from `df -k`.
+type DiskFree struct {
+	Filesystem string
+	TotalKB    int64
+	UsedKB     int64
+	AvailKB    int64
+	MountedOn  string
+}
+
+// GetDiskFree runs `df -k` on sess's host and returns the parsed rows.
+func GetDiskFree(sess *sshsession.Session) ([]DiskFree, error) {
+	output, err := sess.Run("df -k")
+	if err != nil {
+		return nil, fmt.Errorf("df: %w", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	var rows []DiskFree
+	for _, line := range lines[1:] { // skip header
+		fields := strings.Fields(line)
+		if len(fields) < 6 {
+			continue
+		}
+		total, _ := strconv.ParseInt(fields[1], 10, 64)
+		used, _ := strconv.ParseInt(fields[2], 10, 64)
+		avail, _ := strconv.ParseInt(fields[3], 10, 64)
+		rows = append(rows, DiskFree{
+			Filesystem: fields[0],
+			TotalKB:    total,
+			UsedKB:     used,
+			AvailKB:    avail,
+			MountedOn:  fields[5],
+		})
+	}
+	return rows, nil
+}
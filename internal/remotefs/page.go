@@ -0,0 +1,83 @@
+package remotefs
+
+import (
+	"fmt"
+	"sort"
+
+	"sshapp/internal/sshsession"
+)
+
+// SortBy is a field ListDirectoryPage can sort entries by.
+type SortBy string
+
+const (
+	SortByName  SortBy = "name"
+	SortBySize  SortBy = "size"
+	SortByMTime SortBy = "mtime"
+)
+
+// Page is one page of a directory listing, along with a continuation
+// token for fetching the next one.
+type Page struct {
+	Entries []Entry
+	Total   int
+	Token   string // pass to the next ListDirectoryPage call; "" once exhausted
+	HasMore bool
+}
+
+// pageSize caps how many entries one ListDirectoryPage call returns.
+// Each call still reads and sorts the directory's full listing; only the
+// returned slice is limited.
+const pageSize = 500
+
+// ListDirectoryPage returns a sorted, paginated slice of dir's entries.
+// Pass an empty token for the first page, then the returned token for
+// subsequent ones; the token format is "<offset>" and is stable as long
+// as the directory doesn't change between calls.
+func ListDirectoryPage(sess *sshsession.Session, dir string, token string, limit int, sortBy SortBy) (Page, error) {
+	if limit <= 0 || limit > pageSize {
+		limit = pageSize
+	}
+
+	offset := 0
+	if token != "" {
+		if _, err := fmt.Sscanf(token, "%d", &offset); err != nil {
+			return Page{}, fmt.Errorf("invalid page token %q", token)
+		}
+	}
+
+	entries, err := ListDirectory(sess, dir)
+	if err != nil {
+		return Page{}, err
+	}
+	sortEntries(entries, sortBy)
+
+	if offset > len(entries) {
+		offset = len(entries)
+	}
+	end := offset + limit
+	if end > len(entries) {
+		end = len(entries)
+	}
+
+	page := Page{
+		Entries: entries[offset:end],
+		Total:   len(entries),
+		HasMore: end < len(entries),
+	}
+	if page.HasMore {
+		page.Token = fmt.Sprintf("%d", end)
+	}
+	return page, nil
+}
+
+func sortEntries(entries []Entry, sortBy SortBy) {
+	switch sortBy {
+	case SortBySize:
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Size < entries[j].Size })
+	case SortByMTime:
+		sort.Slice(entries, func(i, j int) bool { return entries[i].ModTime.Before(entries[j].ModTime) })
+	default:
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	}
+}
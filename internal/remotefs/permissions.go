@@ -0,0 +1,83 @@
+package remotefs
+
+import (
+	"fmt"
+	"os"
+
+	"sshapp/internal/sshsession"
+)
+
+// SetPermissions chmods path to mode, recursing into directory contents
+// first when recursive is set.
+func SetPermissions(sess *sshsession.Session, path string, mode os.FileMode, recursive bool) error {
+	c, err := client(sess)
+	if err != nil {
+		return err
+	}
+	if recursive {
+		info, err := c.Stat(path)
+		if err != nil {
+			return fmt.Errorf("stat %q: %w", path, err)
+		}
+		if info.IsDir() {
+			entries, err := c.ReadDir(path)
+			if err != nil {
+				return fmt.Errorf("list %q: %w", path, err)
+			}
+			for _, e := range entries {
+				if err := SetPermissions(sess, path+"/"+e.Name(), mode, true); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if err := c.Chmod(path, mode); err != nil {
+		return fmt.Errorf("chmod %q: %w", path, err)
+	}
+	return nil
+}
+
+// SetOwner chowns path to uid:gid, recursing into directory contents
+// first when recursive is set. SFTP chown requires the SSH user to own
+// the file or be root; when it's denied, this falls back to `sudo
+// chown` over an exec channel.
+func SetOwner(sess *sshsession.Session, path string, uid, gid int, recursive bool) error {
+	c, err := client(sess)
+	if err != nil {
+		return err
+	}
+	if recursive {
+		info, err := c.Stat(path)
+		if err != nil {
+			return fmt.Errorf("stat %q: %w", path, err)
+		}
+		if info.IsDir() {
+			entries, err := c.ReadDir(path)
+			if err != nil {
+				return fmt.Errorf("list %q: %w", path, err)
+			}
+			for _, e := range entries {
+				if err := SetOwner(sess, path+"/"+e.Name(), uid, gid, true); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if err := c.Chown(path, uid, gid); err != nil {
+		return sudoChown(sess, path, uid, gid)
+	}
+	return nil
+}
+
+// sudoChown falls back to a `sudo chown` exec when the SFTP subsystem
+// refuses a direct chown (e.g. changing ownership to another user
+// without root privileges over SFTP).
+func sudoChown(sess *sshsession.Session, path string, uid, gid int) error {
+	cmd := fmt.Sprintf("sudo chown %d:%d %s", uid, gid, shellQuote(path))
+	if _, err := sess.Run(cmd); err != nil {
+		return fmt.Errorf("sudo chown %q: %w", path, err)
+	}
+	return nil
+}
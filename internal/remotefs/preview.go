@@ -0,0 +1,69 @@
+package remotefs
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"sshapp/internal/sshsession"
+)
+
+// Preview is a lightweight look at a remote file's content, without
+// downloading the whole thing.
+type Preview struct {
+	MimeType  string
+	IsText    bool
+	IsImage   bool
+	Text      string // set when IsText
+	Base64    string // set when IsImage
+	Truncated bool
+}
+
+// PreviewRemoteFile reads up to maxBytes of remotePath on sess's host
+// and returns a text snippet or a base64-encoded image preview,
+// depending on the detected content type.
+func PreviewRemoteFile(sess *sshsession.Session, remotePath string, maxBytes int) (Preview, error) {
+	if maxBytes <= 0 {
+		maxBytes = 64 * 1024
+	}
+
+	r, err := Open(sess, remotePath, true)
+	if err != nil {
+		return Preview{}, fmt.Errorf("preview %q: %w", remotePath, err)
+	}
+	defer r.Close()
+
+	buf := make([]byte, maxBytes)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return Preview{}, fmt.Errorf("read %q: %w", remotePath, err)
+	}
+	buf = buf[:n]
+
+	// A further byte beyond what we read tells us whether the file was
+	// truncated, without reading the whole rest of it.
+	truncated := false
+	if one := make([]byte, 1); n == maxBytes {
+		if m, _ := r.Read(one); m > 0 {
+			truncated = true
+		}
+	}
+
+	mimeType := http.DetectContentType(buf)
+	preview := Preview{MimeType: mimeType, Truncated: truncated}
+
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		preview.IsImage = true
+		preview.Base64 = base64.StdEncoding.EncodeToString(buf)
+	case strings.HasPrefix(mimeType, "text/") || mimeType == "application/json":
+		preview.IsText = true
+		preview.Text = string(buf)
+	default:
+		// Binary and not an image we can preview inline; report the
+		// MIME type only.
+	}
+	return preview, nil
+}
@@ -0,0 +1,245 @@
+// Package remotefs implements the SFTP-backed file browser: directory
+// listings and file operations against a remote host.
+package remotefs
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/sftp"
+	"sshapp/internal/sshsession"
+)
+
+// Entry is one file or directory returned by ListDirectory.
+type Entry struct {
+	Name        string
+	Path        string
+	IsDir       bool
+	Size        int64
+	Mode        os.FileMode
+	Permissions string // human-readable, e.g. "-rw-r--r--"
+	Owner       string // username when resolvable, else numeric UID
+	Group       string // group name when resolvable, else numeric GID
+	ModTime     time.Time
+	IsSymlink   bool
+	LinkTarget  string // resolved target path, only set when IsSymlink
+	MimeType    string // best-effort guess from the extension, files only
+}
+
+// ListDirectory returns the contents of dir on sess's host.
+func ListDirectory(sess *sshsession.Session, dir string) ([]Entry, error) {
+	c, err := client(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	infos, err := c.ReadDir(dir)
+	if err != nil {
+		invalidate(sess)
+		return nil, fmt.Errorf("list %q: %w", dir, err)
+	}
+
+	entries := make([]Entry, 0, len(infos))
+	for _, info := range infos {
+		entryPath := path.Join(dir, info.Name())
+		entry := Entry{
+			Name:        info.Name(),
+			Path:        entryPath,
+			IsDir:       info.IsDir(),
+			Size:        info.Size(),
+			Mode:        info.Mode(),
+			Permissions: info.Mode().String(),
+			ModTime:     info.ModTime(),
+			IsSymlink:   info.Mode()&os.ModeSymlink != 0,
+		}
+		if st, ok := info.Sys().(*sftp.FileStat); ok {
+			entry.Owner = strconv.FormatUint(uint64(st.UID), 10)
+			entry.Group = strconv.FormatUint(uint64(st.GID), 10)
+		}
+		if entry.IsSymlink {
+			if target, err := c.ReadLink(entryPath); err == nil {
+				entry.LinkTarget = target
+			}
+		} else if !entry.IsDir {
+			if t := mime.TypeByExtension(path.Ext(entry.Name)); t != "" {
+				entry.MimeType = t
+			}
+		}
+		entries = append(entries, entry)
+	}
+	resolveOwnerNames(sess, entries)
+	return entries, nil
+}
+
+// resolveOwnerNames best-effort replaces the numeric UID/GID ownership
+// already filled in from SFTP's FileStat with human-readable names, by
+// asking the remote shell. It leaves the numeric fallback in place when
+// that isn't available.
+func resolveOwnerNames(sess *sshsession.Session, entries []Entry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	var cmd strings.Builder
+	cmd.WriteString("stat -c '%n\t%U\t%G' --")
+	for _, e := range entries {
+		cmd.WriteByte(' ')
+		cmd.WriteString(shellQuote(e.Path))
+	}
+
+	output, err := sess.Run(cmd.String())
+	if err != nil {
+		return
+	}
+
+	byPath := make(map[string]int, len(entries))
+	for i, e := range entries {
+		byPath[e.Path] = i
+	}
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if i, ok := byPath[fields[0]]; ok {
+			entries[i].Owner = fields[1]
+			entries[i].Group = fields[2]
+		}
+	}
+}
+
+// ListOptions filters and sorts a directory listing server-side, so the
+// UI doesn't have to re-process huge listings in JS.
+type ListOptions struct {
+	HideDotfiles bool
+	GlobFilter   string // matched against Entry.Name via path.Match, empty means no filter
+	SortBy       SortBy
+}
+
+// ListDirectoryFiltered returns dir's entries on sess's host, filtered
+// and sorted according to opts.
+func ListDirectoryFiltered(sess *sshsession.Session, dir string, opts ListOptions) ([]Entry, error) {
+	entries, err := ListDirectory(sess, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := entries[:0]
+	for _, e := range entries {
+		if opts.HideDotfiles && strings.HasPrefix(e.Name, ".") {
+			continue
+		}
+		if opts.GlobFilter != "" {
+			matched, err := path.Match(opts.GlobFilter, e.Name)
+			if err != nil {
+				return nil, fmt.Errorf("glob %q: %w", opts.GlobFilter, err)
+			}
+			if !matched {
+				continue
+			}
+		}
+		filtered = append(filtered, e)
+	}
+
+	sortEntries(filtered, opts.SortBy)
+	return filtered, nil
+}
+
+// ReadLink returns the target a symlink points to.
+func ReadLink(sess *sshsession.Session, linkPath string) (string, error) {
+	c, err := client(sess)
+	if err != nil {
+		return "", err
+	}
+
+	target, err := c.ReadLink(linkPath)
+	if err != nil {
+		return "", fmt.Errorf("read link %q: %w", linkPath, err)
+	}
+	return target, nil
+}
+
+// CreateSymlink creates a symlink at linkPath pointing to target.
+func CreateSymlink(sess *sshsession.Session, target, linkPath string) error {
+	c, err := client(sess)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Symlink(target, linkPath); err != nil {
+		return fmt.Errorf("create symlink %q -> %q: %w", linkPath, target, err)
+	}
+	return nil
+}
+
+// ErrIsSymlink is returned by Open when followSymlink is false and
+// remotePath is itself a symlink, so the caller can recreate the link
+// locally (e.g. via CreateSymlink) instead of downloading its target's
+// content.
+var ErrIsSymlink = fmt.Errorf("path is a symlink")
+
+// Open opens remotePath for reading as a download source. When
+// followSymlink is false and remotePath is a symlink, it returns
+// ErrIsSymlink instead of transparently downloading the target's
+// content.
+func Open(sess *sshsession.Session, remotePath string, followSymlink bool) (io.ReadCloser, error) {
+	c, err := client(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	if !followSymlink {
+		if info, err := c.Lstat(remotePath); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			return nil, ErrIsSymlink
+		}
+	}
+
+	f, err := c.Open(remotePath)
+	if err != nil {
+		invalidate(sess)
+		return nil, fmt.Errorf("open %q: %w", remotePath, err)
+	}
+	return f, nil
+}
+
+// Write creates (or truncates) remotePath and copies data into it.
+func Write(sess *sshsession.Session, remotePath string, data io.Reader) error {
+	c, err := client(sess)
+	if err != nil {
+		return err
+	}
+
+	w, err := c.Create(remotePath)
+	if err != nil {
+		invalidate(sess)
+		return fmt.Errorf("create %q: %w", remotePath, err)
+	}
+	defer w.Close()
+
+	if _, err := w.ReadFrom(data); err != nil {
+		invalidate(sess)
+		return fmt.Errorf("write %q: %w", remotePath, err)
+	}
+	return nil
+}
+
+// Stat returns file info for path. When followSymlink is true and path
+// is a symlink, it returns info about the link's ultimate target
+// instead of the link itself.
+func Stat(sess *sshsession.Session, path string, followSymlink bool) (os.FileInfo, error) {
+	c, err := client(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	if followSymlink {
+		return c.Stat(path)
+	}
+	return c.Lstat(path)
+}
@@ -0,0 +1,116 @@
+package remotefs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+
+	"sshapp/internal/sshsession"
+)
+
+// sftpAvailable reports whether sess's host has a working SFTP
+// subsystem. Some hardened servers disable it entirely while still
+// allowing normal shell exec, so file transfers need a `cat`-over-exec
+// fallback for those hosts.
+func sftpAvailable(sess *sshsession.Session) bool {
+	_, err := client(sess)
+	return err == nil
+}
+
+// OpenAuto opens remotePath for reading, using SFTP when available and
+// falling back to `cat` over a raw exec channel otherwise. Unlike Open,
+// the fallback path can't distinguish a symlink from its target.
+func OpenAuto(sess *sshsession.Session, remotePath string) (io.ReadCloser, error) {
+	if sftpAvailable(sess) {
+		return Open(sess, remotePath, true)
+	}
+	return catOpen(sess, remotePath)
+}
+
+// WriteAuto writes data to remotePath, using SFTP when available and
+// falling back to `cat > file` over a raw exec channel otherwise.
+func WriteAuto(sess *sshsession.Session, remotePath string, data io.Reader) error {
+	if sftpAvailable(sess) {
+		return Write(sess, remotePath, data)
+	}
+	return catWrite(sess, remotePath, data)
+}
+
+// StatSizeAuto returns remotePath's size in bytes, using SFTP when
+// available and falling back to `wc -c` otherwise.
+func StatSizeAuto(sess *sshsession.Session, remotePath string) (int64, error) {
+	if sftpAvailable(sess) {
+		info, err := Stat(sess, remotePath, true)
+		if err != nil {
+			return 0, err
+		}
+		return info.Size(), nil
+	}
+
+	output, err := sess.Run(fmt.Sprintf("wc -c < %s", shellQuote(remotePath)))
+	if err != nil {
+		return 0, fmt.Errorf("stat %q: %w", remotePath, err)
+	}
+	var size int64
+	if _, err := fmt.Sscanf(strings.TrimSpace(output), "%d", &size); err != nil {
+		return 0, fmt.Errorf("stat %q: parse size: %w", remotePath, err)
+	}
+	return size, nil
+}
+
+func catOpen(sess *sshsession.Session, remotePath string) (io.ReadCloser, error) {
+	session, err := sess.UnderlyingClient().NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("open %q: %w", remotePath, err)
+	}
+
+	out, err := session.StdoutPipe()
+	if err != nil {
+		session.Close()
+		return nil, fmt.Errorf("open %q: %w", remotePath, err)
+	}
+
+	if err := session.Start("cat " + shellQuote(remotePath)); err != nil {
+		session.Close()
+		return nil, fmt.Errorf("open %q: %w", remotePath, err)
+	}
+	return &sessionReader{Reader: out, session: session}, nil
+}
+
+// sessionReader adapts a raw exec session's stdout into an
+// io.ReadCloser, closing the session (and its channel) once the caller
+// is done reading.
+type sessionReader struct {
+	io.Reader
+	session *ssh.Session
+}
+
+func (s *sessionReader) Close() error {
+	return s.session.Close()
+}
+
+func catWrite(sess *sshsession.Session, remotePath string, data io.Reader) error {
+	session, err := sess.UnderlyingClient().NewSession()
+	if err != nil {
+		return fmt.Errorf("write %q: %w", remotePath, err)
+	}
+	defer session.Close()
+
+	in, err := session.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("write %q: %w", remotePath, err)
+	}
+
+	if err := session.Start("cat > " + shellQuote(remotePath)); err != nil {
+		return fmt.Errorf("write %q: %w", remotePath, err)
+	}
+	if _, err := io.Copy(in, data); err != nil {
+		return fmt.Errorf("write %q: %w", remotePath, err)
+	}
+	if err := in.Close(); err != nil {
+		return fmt.Errorf("write %q: %w", remotePath, err)
+	}
+	return session.Wait()
+}
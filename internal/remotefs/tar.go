@@ -0,0 +1,239 @@
+package remotefs
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sshapp/internal/sshsession"
+)
+
+// hasTar reports whether sess's remote shell has a tar binary on PATH.
+func hasTar(sess *sshsession.Session) bool {
+	_, err := sess.Run("command -v tar")
+	return err == nil
+}
+
+// UploadTree uploads every file under localDir to remoteDir. When the
+// remote shell has tar available, it streams the whole tree as a single
+// tar.gz over one exec channel instead of one SFTP round-trip per file,
+// which is far faster for trees with many small files. It falls back to
+// a plain per-file SFTP upload when tar isn't available.
+func UploadTree(sess *sshsession.Session, localDir, remoteDir string) error {
+	if !hasTar(sess) {
+		return uploadTreeSFTP(sess, localDir, remoteDir)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(tarLocalDir(localDir, pw))
+	}()
+
+	client, err := sess.UnderlyingClient().NewSession()
+	if err != nil {
+		return fmt.Errorf("open exec session: %w", err)
+	}
+	defer client.Close()
+
+	client.Stdin = pr
+	quoted := shellQuote(remoteDir)
+	cmd := fmt.Sprintf("mkdir -p %s && tar -xzf - -C %s", quoted, quoted)
+	if err := client.Run(cmd); err != nil {
+		return fmt.Errorf("untar into %q: %w", remoteDir, err)
+	}
+	return nil
+}
+
+// DownloadTree downloads remoteDir from sess's host into localDir,
+// symmetric to UploadTree: one tar.gz stream when tar is available,
+// per-file SFTP otherwise.
+func DownloadTree(sess *sshsession.Session, remoteDir, localDir string) error {
+	if !hasTar(sess) {
+		return downloadTreeSFTP(sess, remoteDir, localDir)
+	}
+
+	session, err := sess.UnderlyingClient().NewSession()
+	if err != nil {
+		return fmt.Errorf("open exec session: %w", err)
+	}
+	defer session.Close()
+
+	out, err := session.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("open stdout pipe: %w", err)
+	}
+
+	cmd := fmt.Sprintf("tar -czf - -C %s .", shellQuote(remoteDir))
+	if err := session.Start(cmd); err != nil {
+		return fmt.Errorf("start remote tar: %w", err)
+	}
+
+	if err := untarLocalDir(out, localDir); err != nil {
+		return err
+	}
+	if err := session.Wait(); err != nil {
+		return fmt.Errorf("remote tar: %w", err)
+	}
+	return nil
+}
+
+func tarLocalDir(localDir string, w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	err := filepath.Walk(localDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(localDir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = rel
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("tar %q: %w", localDir, err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("close tar stream: %w", err)
+	}
+	return gz.Close()
+}
+
+func untarLocalDir(r io.Reader, localDir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("open gzip stream: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read tar stream: %w", err)
+		}
+
+		target := filepath.Join(localDir, header.Name)
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func uploadTreeSFTP(sess *sshsession.Session, localDir, remoteDir string) error {
+	return filepath.Walk(localDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(localDir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		remotePath := remoteDir + "/" + strings.ReplaceAll(rel, string(filepath.Separator), "/")
+
+		c, err := client(sess)
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return c.MkdirAll(remotePath)
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return Write(sess, remotePath, f)
+	})
+}
+
+func downloadTreeSFTP(sess *sshsession.Session, remoteDir, localDir string) error {
+	entries, err := ListDirectory(sess, remoteDir)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(localDir, 0o755); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		localPath := filepath.Join(localDir, e.Name)
+		if e.IsDir {
+			if err := downloadTreeSFTP(sess, e.Path, localPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		r, err := Open(sess, e.Path, true)
+		if err != nil {
+			return err
+		}
+		f, err := os.Create(localPath)
+		if err != nil {
+			r.Close()
+			return err
+		}
+		_, err = f.ReadFrom(r)
+		r.Close()
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
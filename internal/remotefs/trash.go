@@ -0,0 +1,146 @@
+package remotefs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	"sshapp/internal/sshsession"
+)
+
+const trashDir = ".terminalapp_trash"
+const trashManifest = trashDir + "/manifest.json"
+
+// TrashedItem records where a trashed file came from, so it can be
+// restored to its original location later.
+type TrashedItem struct {
+	ID           string
+	OriginalPath string
+	TrashedAt    time.Time
+}
+
+// DeleteRemoteFile removes path from sess's host. When useTrash is set,
+// it's moved into ~/.terminalapp_trash instead of being unlinked, so it
+// can be listed and restored later via ListTrash/RestoreFromTrash.
+func DeleteRemoteFile(sess *sshsession.Session, path string, useTrash bool) error {
+	if !useTrash {
+		c, err := client(sess)
+		if err != nil {
+			return err
+		}
+		if err := c.Remove(path); err != nil {
+			return fmt.Errorf("delete %q: %w", path, err)
+		}
+		return nil
+	}
+	return moveToTrash(sess, path)
+}
+
+func moveToTrash(sess *sshsession.Session, originalPath string) error {
+	manifest, err := loadTrashManifest(sess)
+	if err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf("%d", len(manifest)+1)
+	trashedPath := trashPathFor(id, originalPath)
+
+	if err := CopyRemote(sess, originalPath, trashedPath); err != nil {
+		return fmt.Errorf("move %q to trash: %w", originalPath, err)
+	}
+	if _, err := sess.Run(fmt.Sprintf("rm -rf %s", shellQuote(originalPath))); err != nil {
+		return fmt.Errorf("remove %q after trashing: %w", originalPath, err)
+	}
+
+	manifest = append(manifest, TrashedItem{ID: id, OriginalPath: originalPath, TrashedAt: time.Now()})
+	return saveTrashManifest(sess, manifest)
+}
+
+func trashPathFor(id, originalPath string) string {
+	return path.Join(trashDir, id+"_"+path.Base(originalPath))
+}
+
+// ListTrash returns every item currently in sess's remote trash.
+func ListTrash(sess *sshsession.Session) ([]TrashedItem, error) {
+	return loadTrashManifest(sess)
+}
+
+// RestoreFromTrash moves a trashed item back to its original path.
+func RestoreFromTrash(sess *sshsession.Session, id string) error {
+	manifest, err := loadTrashManifest(sess)
+	if err != nil {
+		return err
+	}
+
+	for i, item := range manifest {
+		if item.ID != id {
+			continue
+		}
+
+		trashedPath := trashPathFor(id, item.OriginalPath)
+		if err := MoveRemote(sess, trashedPath, item.OriginalPath); err != nil {
+			return fmt.Errorf("restore %q: %w", item.OriginalPath, err)
+		}
+
+		manifest = append(manifest[:i], manifest[i+1:]...)
+		return saveTrashManifest(sess, manifest)
+	}
+	return fmt.Errorf("trashed item %q not found", id)
+}
+
+// PurgeTrash permanently deletes a trashed item (or every item, when id
+// is empty).
+func PurgeTrash(sess *sshsession.Session, id string) error {
+	manifest, err := loadTrashManifest(sess)
+	if err != nil {
+		return err
+	}
+
+	if id == "" {
+		if _, err := sess.Run(fmt.Sprintf("rm -rf %s", shellQuote(trashDir))); err != nil {
+			return fmt.Errorf("purge trash: %w", err)
+		}
+		return saveTrashManifest(sess, nil)
+	}
+
+	for i, item := range manifest {
+		if item.ID != id {
+			continue
+		}
+		if _, err := sess.Run(fmt.Sprintf("rm -rf %s", shellQuote(trashPathFor(id, item.OriginalPath)))); err != nil {
+			return fmt.Errorf("purge %q: %w", item.OriginalPath, err)
+		}
+		manifest = append(manifest[:i], manifest[i+1:]...)
+		return saveTrashManifest(sess, manifest)
+	}
+	return fmt.Errorf("trashed item %q not found", id)
+}
+
+func loadTrashManifest(sess *sshsession.Session) ([]TrashedItem, error) {
+	r, err := Open(sess, trashManifest, true)
+	if err != nil {
+		return nil, nil
+	}
+	defer r.Close()
+
+	var manifest []TrashedItem
+	if err := json.NewDecoder(r).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("parse trash manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+func saveTrashManifest(sess *sshsession.Session, manifest []TrashedItem) error {
+	if _, err := sess.Run(fmt.Sprintf("mkdir -p %s", shellQuote(trashDir))); err != nil {
+		return fmt.Errorf("create trash dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode trash manifest: %w", err)
+	}
+
+	return Write(sess, trashManifest, bytes.NewReader(data))
+}
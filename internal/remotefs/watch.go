@@ -0,0 +1,109 @@
+package remotefs
+
+import (
+	"bufio"
+	"strings"
+	"time"
+
+	"sshapp/internal/filewatch"
+	"sshapp/internal/sshsession"
+)
+
+// DirLister adapts ListDirectory to filewatch.Lister, so WatchRemotePath
+// can reuse the same poll-and-diff machinery filewatch already provides
+// for remote directories.
+type DirLister struct {
+	Sess *sshsession.Session
+}
+
+// List implements filewatch.Lister.
+func (d DirLister) List(dir string) (map[string]time.Time, error) {
+	entries, err := ListDirectory(d.Sess, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	modTimes := make(map[string]time.Time, len(entries))
+	for _, e := range entries {
+		modTimes[e.Path] = e.ModTime
+	}
+	return modTimes, nil
+}
+
+// hasInotifywait reports whether sess's remote shell has inotifywait on
+// PATH.
+func hasInotifywait(sess *sshsession.Session) bool {
+	_, err := sess.Run("command -v inotifywait")
+	return err == nil
+}
+
+const pollInterval = 2 * time.Second
+
+// WatchRemotePath watches path on sess's host for changes until stop is
+// closed, calling onChange for each one. It prefers inotifywait (an
+// exec channel streaming real kernel change events) when it's on the
+// remote PATH, falling back to polling via filewatch otherwise. It
+// returns once the watch has started; the watch runs in the background.
+func WatchRemotePath(sess *sshsession.Session, path string, onChange func(filewatch.Change), stop <-chan struct{}) error {
+	if hasInotifywait(sess) {
+		return watchWithInotify(sess, path, onChange, stop)
+	}
+	lister := DirLister{Sess: sess}
+	if _, err := lister.List(path); err != nil {
+		return err
+	}
+	go filewatch.Watch(lister, path, pollInterval, onChange, stop)
+	return nil
+}
+
+func watchWithInotify(sess *sshsession.Session, path string, onChange func(filewatch.Change), stop <-chan struct{}) error {
+	session, err := sess.UnderlyingClient().NewSession()
+	if err != nil {
+		return err
+	}
+
+	out, err := session.StdoutPipe()
+	if err != nil {
+		session.Close()
+		return err
+	}
+
+	cmd := "inotifywait -m -r --format '%w%f %e' " + shellQuote(path)
+	if err := session.Start(cmd); err != nil {
+		session.Close()
+		return err
+	}
+
+	go func() {
+		<-stop
+		session.Close()
+	}()
+
+	scanner := bufio.NewScanner(out)
+	go func() {
+		defer session.Close()
+		for scanner.Scan() {
+			fields := strings.SplitN(scanner.Text(), " ", 2)
+			if len(fields) != 2 {
+				continue
+			}
+			onChange(filewatch.Change{Path: fields[0], Kind: inotifyKind(fields[1])})
+		}
+	}()
+	return nil
+}
+
+func inotifyKind(events string) filewatch.ChangeKind {
+	switch {
+	case strings.Contains(events, "CREATE"):
+		return filewatch.Added
+	case strings.Contains(events, "DELETE"):
+		return filewatch.Removed
+	default:
+		return filewatch.Modified
+	}
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
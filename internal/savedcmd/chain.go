@@ -0,0 +1,93 @@
+package savedcmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Condition gates whether a chain step runs, based on the previous
+// step's outcome.
+type Condition string
+
+const (
+	Always    Condition = "always"
+	OnSuccess Condition = "on_success"
+	OnFailure Condition = "on_failure"
+	OutputHas Condition = "output_contains"
+)
+
+// ChainStep is one command in a saved command chain.
+type ChainStep struct {
+	CommandID string    `json:"command_id"`
+	If        Condition `json:"if"`
+	Match     string    `json:"match,omitempty"` // used when If == OutputHas
+}
+
+// Chain is an ordered list of saved commands, each gated by a condition
+// evaluated against the previous step's result.
+type Chain struct {
+	ID    string      `json:"id"`
+	Name  string      `json:"name"`
+	Steps []ChainStep `json:"steps"`
+}
+
+// StepResult is the outcome of running one chain step, used to evaluate
+// the next step's condition.
+type StepResult struct {
+	Output string
+	Err    error
+}
+
+// ShouldRun reports whether step should execute given the previous
+// step's result. The first step in a chain has no previous result and
+// always runs.
+func (step ChainStep) ShouldRun(prev *StepResult) bool {
+	if prev == nil {
+		return true
+	}
+	switch step.If {
+	case OnSuccess, "":
+		return prev.Err == nil
+	case OnFailure:
+		return prev.Err != nil
+	case OutputHas:
+		return strings.Contains(prev.Output, step.Match)
+	case Always:
+		return true
+	default:
+		return false
+	}
+}
+
+// Runner executes a single saved command (already rendered, with any
+// template variables filled in) and returns its output.
+type Runner interface {
+	Run(command string) (string, error)
+}
+
+// RunChain executes chain's steps in order against runner, skipping any
+// step whose condition is not satisfied, and calls onStep after each
+// executed step.
+func RunChain(store *Store, chain Chain, runner Runner, onStep func(ChainStep, StepResult)) error {
+	var prev *StepResult
+
+	for _, step := range chain.Steps {
+		if !step.ShouldRun(prev) {
+			continue
+		}
+
+		cmd, err := store.Get(step.CommandID)
+		if err != nil {
+			return fmt.Errorf("chain %q: %w", chain.ID, err)
+		}
+
+		output, err := runner.Run(cmd.Command)
+		result := StepResult{Output: output, Err: err}
+		if onStep != nil {
+			onStep(step, result)
+		}
+		prev = &result
+	}
+
+	return nil
+}
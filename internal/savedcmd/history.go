@@ -0,0 +1,84 @@
+package savedcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const editHistoryPath = "./history/saved_commands_history.json"
+
+// edit is one saved revision of a command, recorded before it is
+// overwritten.
+type edit struct {
+	CommandID string   `json:"command_id"`
+	Previous  *Command `json:"previous"`
+}
+
+func loadEditHistory() ([]edit, error) {
+	data, err := os.ReadFile(editHistoryPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read saved command edit history: %w", err)
+	}
+
+	var edits []edit
+	if err := json.Unmarshal(data, &edits); err != nil {
+		return nil, fmt.Errorf("parse saved command edit history: %w", err)
+	}
+	return edits, nil
+}
+
+func appendEditHistory(e edit) error {
+	edits, err := loadEditHistory()
+	if err != nil {
+		return err
+	}
+	edits = append(edits, e)
+
+	data, err := json.MarshalIndent(edits, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode saved command edit history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(editHistoryPath), 0o755); err != nil {
+		return fmt.Errorf("create saved command edit history dir: %w", err)
+	}
+	return os.WriteFile(editHistoryPath, data, 0o644)
+}
+
+// PutWithHistory behaves like Put, but first records the command's
+// previous value (if any) so it can be rolled back to later.
+func (s *Store) PutWithHistory(c *Command) error {
+	if prev, err := s.Get(c.ID); err == nil {
+		if err := appendEditHistory(edit{CommandID: c.ID, Previous: prev}); err != nil {
+			return err
+		}
+	}
+	return s.Put(c)
+}
+
+// EditHistory returns the recorded previous versions of a command,
+// oldest first.
+func (s *Store) EditHistory(commandID string) ([]*Command, error) {
+	edits, err := loadEditHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*Command
+	for _, e := range edits {
+		if e.CommandID == commandID {
+			out = append(out, e.Previous)
+		}
+	}
+	return out, nil
+}
+
+// Rollback restores a command to a previous version recorded in its
+// edit history.
+func (s *Store) Rollback(commandID string, toVersion *Command) error {
+	return s.PutWithHistory(toVersion)
+}
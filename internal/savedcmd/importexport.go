@@ -0,0 +1,32 @@
+package savedcmd
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Export serializes every saved command to a portable JSON document.
+func (s *Store) Export() ([]byte, error) {
+	list := s.List()
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode saved commands export: %w", err)
+	}
+	return data, nil
+}
+
+// Import merges the commands in data into the store, overwriting any
+// existing command with the same ID.
+func (s *Store) Import(data []byte) (int, error) {
+	var list []*Command
+	if err := json.Unmarshal(data, &list); err != nil {
+		return 0, fmt.Errorf("parse saved commands import: %w", err)
+	}
+
+	for _, c := range list {
+		if err := s.Put(c); err != nil {
+			return 0, fmt.Errorf("import command %q: %w", c.ID, err)
+		}
+	}
+	return len(list), nil
+}
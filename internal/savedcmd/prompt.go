@@ -0,0 +1,16 @@
+package savedcmd
+
+import "sshapp/internal/template"
+
+// PromptVariables returns the template variable names a saved command
+// references, so the UI can prompt the user for each one before running
+// it.
+func (c *Command) PromptVariables() []string {
+	return template.Variables(c.Command)
+}
+
+// Render expands a saved command's template variables with the given
+// values, returning the concrete command to run.
+func (c *Command) Render(vars map[string]string) (string, error) {
+	return template.Render(c.Command, vars)
+}
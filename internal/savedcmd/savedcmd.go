@@ -0,0 +1,184 @@
+// Package savedcmd manages the user's saved command snippets: organizing
+// them into folders and tags for quick recall from the command palette.
+package savedcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Command is a single saved command snippet. A Command with an empty
+// Profile is global (available from any connection); otherwise it is
+// scoped to that one profile.
+type Command struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Command string   `json:"command"`
+	Folder  string   `json:"folder,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+	Profile string   `json:"profile,omitempty"`
+
+	// Shortcut is a key chord (e.g. "Ctrl+Shift+1") that triggers this
+	// command from anywhere in the app; empty means unbound.
+	Shortcut string `json:"shortcut,omitempty"`
+}
+
+const storePath = "./history/saved_commands.json"
+
+// Store holds the saved commands, persisted as a single JSON file.
+type Store struct {
+	mu       sync.Mutex
+	path     string
+	commands map[string]*Command
+}
+
+// NewEmpty returns a Store with no saved commands loaded.
+func NewEmpty() *Store {
+	return &Store{path: storePath, commands: make(map[string]*Command)}
+}
+
+// Load reads the saved commands from disk, returning an empty Store if
+// none have been saved yet.
+func Load() (*Store, error) {
+	s := NewEmpty()
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read saved commands: %w", err)
+	}
+
+	var list []*Command
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("parse saved commands: %w", err)
+	}
+	for _, c := range list {
+		s.commands[c.ID] = c
+	}
+	return s, nil
+}
+
+func (s *Store) save() error {
+	list := make([]*Command, 0, len(s.commands))
+	for _, c := range s.commands {
+		list = append(list, c)
+	}
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode saved commands: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("create saved commands dir: %w", err)
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
+
+// Put creates or updates a saved command.
+func (s *Store) Put(c *Command) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.commands[c.ID] = c
+	return s.save()
+}
+
+// Get returns the saved command with the given ID, or an error if it
+// does not exist.
+func (s *Store) Get(id string) (*Command, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.commands[id]
+	if !ok {
+		return nil, fmt.Errorf("saved command %q not found", id)
+	}
+	return c, nil
+}
+
+// Delete removes a saved command by ID.
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.commands, id)
+	return s.save()
+}
+
+// List returns every saved command.
+func (s *Store) List() []*Command {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]*Command, 0, len(s.commands))
+	for _, c := range s.commands {
+		out = append(out, c)
+	}
+	return out
+}
+
+// ListFolder returns the saved commands in the given folder.
+func (s *Store) ListFolder(folder string) []*Command {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []*Command
+	for _, c := range s.commands {
+		if c.Folder == folder {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// ListForProfile returns every command usable from profileName: the
+// commands scoped to that profile plus every global command.
+func (s *Store) ListForProfile(profileName string) []*Command {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []*Command
+	for _, c := range s.commands {
+		if c.Profile == "" || c.Profile == profileName {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// ListGlobal returns the commands available from every profile.
+func (s *Store) ListGlobal() []*Command {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []*Command
+	for _, c := range s.commands {
+		if c.Profile == "" {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// ListTag returns the saved commands carrying the given tag.
+func (s *Store) ListTag(tag string) []*Command {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []*Command
+	for _, c := range s.commands {
+		for _, t := range c.Tags {
+			if t == tag {
+				out = append(out, c)
+				break
+			}
+		}
+	}
+	return out
+}
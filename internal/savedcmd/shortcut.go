@@ -0,0 +1,36 @@
+package savedcmd
+
+import "fmt"
+
+// ByShortcut returns the saved command bound to chord, or an error if
+// none is.
+func (s *Store) ByShortcut(chord string) (*Command, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.commands {
+		if c.Shortcut == chord {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("no command bound to %q", chord)
+}
+
+// SetShortcut binds chord to a saved command, clearing it from any other
+// command that previously held it (chords must be unique).
+func (s *Store) SetShortcut(commandID, chord string) error {
+	s.mu.Lock()
+	for _, c := range s.commands {
+		if c.Shortcut == chord && c.ID != commandID {
+			c.Shortcut = ""
+		}
+	}
+	c, ok := s.commands[commandID]
+	s.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("saved command %q not found", commandID)
+	}
+	c.Shortcut = chord
+	return s.save()
+}
@@ -0,0 +1,110 @@
+package savedcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const statsPath = "./history/saved_commands_stats.json"
+
+// Stats tracks how often and how successfully a saved command has run.
+type Stats struct {
+	CommandID string        `json:"command_id"`
+	RunCount  int           `json:"run_count"`
+	FailCount int           `json:"fail_count"`
+	TotalTime time.Duration `json:"total_time"`
+	LastRunAt time.Time     `json:"last_run_at"`
+}
+
+// AvgDuration returns the average execution time across recorded runs.
+func (s Stats) AvgDuration() time.Duration {
+	if s.RunCount == 0 {
+		return 0
+	}
+	return s.TotalTime / time.Duration(s.RunCount)
+}
+
+// StatsStore persists execution statistics for saved commands.
+type StatsStore struct {
+	mu    sync.Mutex
+	stats map[string]*Stats
+}
+
+// NewEmptyStats returns a StatsStore with no statistics loaded.
+func NewEmptyStats() *StatsStore {
+	return &StatsStore{stats: make(map[string]*Stats)}
+}
+
+// LoadStats reads saved execution statistics from disk.
+func LoadStats() (*StatsStore, error) {
+	s := NewEmptyStats()
+
+	data, err := os.ReadFile(statsPath)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read saved command stats: %w", err)
+	}
+
+	var list []*Stats
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("parse saved command stats: %w", err)
+	}
+	for _, st := range list {
+		s.stats[st.CommandID] = st
+	}
+	return s, nil
+}
+
+func (s *StatsStore) save() error {
+	list := make([]*Stats, 0, len(s.stats))
+	for _, st := range s.stats {
+		list = append(list, st)
+	}
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode saved command stats: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(statsPath), 0o755); err != nil {
+		return fmt.Errorf("create saved command stats dir: %w", err)
+	}
+	return os.WriteFile(statsPath, data, 0o644)
+}
+
+// Record updates a command's statistics after an execution.
+func (s *StatsStore) Record(commandID string, duration time.Duration, failed bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.stats[commandID]
+	if !ok {
+		st = &Stats{CommandID: commandID}
+		s.stats[commandID] = st
+	}
+	st.RunCount++
+	if failed {
+		st.FailCount++
+	}
+	st.TotalTime += duration
+	st.LastRunAt = time.Now()
+
+	return s.save()
+}
+
+// Get returns the recorded statistics for a command, or a zero Stats if
+// it has never been run.
+func (s *StatsStore) Get(commandID string) Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if st, ok := s.stats[commandID]; ok {
+		return *st
+	}
+	return Stats{CommandID: commandID}
+}
@@ -0,0 +1,115 @@
+// Package scheduler runs saved commands against profiles on a cron-like
+// schedule, independent of whether the user currently has a session open
+// to that host.
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Job is a command scheduled to run repeatedly against a profile.
+type Job struct {
+	ID       string
+	Profile  string
+	Command  string
+	Interval time.Duration
+
+	stop chan struct{}
+}
+
+// Runner executes a scheduled command against a profile.
+type Runner interface {
+	Run(profile, command string) (output string, err error)
+}
+
+// Scheduler owns the set of active jobs and drives them on their own
+// timers.
+type Scheduler struct {
+	runner Runner
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+
+	onResult func(jobID, output string, err error)
+}
+
+// New returns a Scheduler that executes jobs via runner.
+func New(runner Runner) *Scheduler {
+	return &Scheduler{runner: runner, jobs: make(map[string]*Job)}
+}
+
+// OnResult registers the callback invoked after each scheduled run.
+func (s *Scheduler) OnResult(fn func(jobID, output string, err error)) {
+	s.mu.Lock()
+	s.onResult = fn
+	s.mu.Unlock()
+}
+
+// Schedule starts running job on its own interval until Cancel is called.
+func (s *Scheduler) Schedule(job *Job) error {
+	if job.Interval <= 0 {
+		return fmt.Errorf("job %q: interval must be positive", job.ID)
+	}
+
+	s.mu.Lock()
+	if _, exists := s.jobs[job.ID]; exists {
+		s.mu.Unlock()
+		return fmt.Errorf("job %q already scheduled", job.ID)
+	}
+	job.stop = make(chan struct{})
+	s.jobs[job.ID] = job
+	s.mu.Unlock()
+
+	go s.run(job)
+	return nil
+}
+
+func (s *Scheduler) run(job *Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-job.stop:
+			return
+		case <-ticker.C:
+			output, err := s.runner.Run(job.Profile, job.Command)
+
+			s.mu.Lock()
+			cb := s.onResult
+			s.mu.Unlock()
+
+			if cb != nil {
+				cb(job.ID, output, err)
+			}
+		}
+	}
+}
+
+// Cancel stops a scheduled job and removes it.
+func (s *Scheduler) Cancel(jobID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[jobID]
+	if !ok {
+		return fmt.Errorf("job %q not found", jobID)
+	}
+	close(job.stop)
+	delete(s.jobs, jobID)
+	return nil
+}
+
+// List returns the currently scheduled jobs.
+func (s *Scheduler) List() []*Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]*Job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		out = append(out, j)
+	}
+	return out
+}
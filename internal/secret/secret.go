@@ -0,0 +1,47 @@
+// Package secret holds saved passwords in memory in a way that resists
+// casual replay: each secret can be taken at most once before its
+// plaintext is wiped, forcing callers to re-derive or re-prompt rather
+// than keeping a long-lived copy around to reuse.
+package secret
+
+import "sync"
+
+// String is a password (or similarly sensitive value) held in memory.
+// It is safe for concurrent use.
+type String struct {
+	mu    sync.Mutex
+	bytes []byte
+	used  bool
+}
+
+// New wraps value in a String. The caller's copy of value is not wiped;
+// callers should discard their own reference after calling New.
+func New(value string) *String {
+	return &String{bytes: []byte(value)}
+}
+
+// Take returns the plaintext value the first time it is called, then
+// wipes it from memory; every subsequent call returns "", false.
+func (s *String) Take() (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.used {
+		return "", false
+	}
+	s.used = true
+
+	value := string(s.bytes)
+	for i := range s.bytes {
+		s.bytes[i] = 0
+	}
+	s.bytes = nil
+	return value, true
+}
+
+// Used reports whether Take has already consumed this secret.
+func (s *String) Used() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.used
+}
@@ -0,0 +1,137 @@
+package selfupdate
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []int
+		wantErr bool
+	}{
+		{in: "1.2.3", want: []int{1, 2, 3}},
+		{in: "v1.2.3", want: []int{1, 2, 3}},
+		{in: " 1.2.3 ", want: []int{1, 2, 3}},
+		{in: "dev", wantErr: true},
+		{in: "1.2.x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseVersion(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseVersion(%q) = %v, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseVersion(%q): unexpected error %v", tt.in, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("parseVersion(%q) = %v, want %v", tt.in, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("parseVersion(%q) = %v, want %v", tt.in, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+// TestCompareVersionsNumeric is the test the shipped `!=` version-string
+// comparison bug would have failed: "1.10.0" must compare newer than
+// "1.9.0", even though "1.10.0" < "1.9.0" lexically.
+func TestCompareVersionsNumeric(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int // sign only
+	}{
+		{a: "1.10.0", b: "1.9.0", want: 1},
+		{a: "1.9.0", b: "1.10.0", want: -1},
+		{a: "1.2.0", b: "1.2.0", want: 0},
+		{a: "2.0.0", b: "1.9.9", want: 1},
+		{a: "1.2", b: "1.2.0", want: 0},
+	}
+
+	for _, tt := range tests {
+		a, err := parseVersion(tt.a)
+		if err != nil {
+			t.Fatalf("parseVersion(%q): %v", tt.a, err)
+		}
+		b, err := parseVersion(tt.b)
+		if err != nil {
+			t.Fatalf("parseVersion(%q): %v", tt.b, err)
+		}
+		got := compareVersions(a, b)
+		gotSign := sign(got)
+		if gotSign != tt.want {
+			t.Errorf("compareVersions(%q, %q) sign = %d, want %d", tt.a, tt.b, gotSign, tt.want)
+		}
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func TestVerifyChecksumAndSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	oldKey := releasePublicKeyHex
+	releasePublicKeyHex = hex.EncodeToString(pub)
+	t.Cleanup(func() { releasePublicKeyHex = oldKey })
+
+	data := []byte("a whole release binary's worth of bytes")
+	sum := sha256.Sum256(data)
+	sig := ed25519.Sign(priv, data)
+
+	rel := &Release{
+		SHA256:    hex.EncodeToString(sum[:]),
+		Signature: base64.StdEncoding.EncodeToString(sig),
+	}
+
+	if err := verify(data, rel); err != nil {
+		t.Fatalf("verify() with valid data: %v", err)
+	}
+
+	if err := verify([]byte("tampered bytes"), rel); err == nil {
+		t.Fatalf("verify() with tampered data: want error, got nil")
+	}
+
+	badSig := &Release{SHA256: rel.SHA256, Signature: base64.StdEncoding.EncodeToString([]byte("not a real signature"))}
+	if err := verify(data, badSig); err == nil {
+		t.Fatalf("verify() with bad signature: want error, got nil")
+	}
+}
+
+func TestVerifyRequiresEmbeddedPublicKey(t *testing.T) {
+	oldKey := releasePublicKeyHex
+	releasePublicKeyHex = ""
+	t.Cleanup(func() { releasePublicKeyHex = oldKey })
+
+	data := []byte("some build")
+	sum := sha256.Sum256(data)
+	rel := &Release{SHA256: hex.EncodeToString(sum[:])}
+
+	if err := verify(data, rel); err == nil {
+		t.Fatalf("verify() with no embedded public key: want error, got nil")
+	}
+}
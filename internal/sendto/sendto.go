@@ -0,0 +1,92 @@
+// Package sendto implements post-execution "send to" actions on captured
+// terminal output: saving it as a note attached to a host, exporting it to
+// a paste/gist service, or writing it into the local notes directory with
+// frontmatter.
+package sendto
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Result is a captured chunk of terminal output a user wants to act on.
+type Result struct {
+	Host    string
+	Command string
+	Output  string
+	When    time.Time
+}
+
+// PasteConfig configures the external paste/gist service output is
+// exported to.
+type PasteConfig struct {
+	Endpoint string // e.g. a gist-compatible POST endpoint
+	APIToken string
+}
+
+// notesDir is where local notes are written, mirroring the ./configs and
+// ./history layout described in the README.
+const notesDir = "./notes"
+
+// SaveAsNote writes the result into notesDir as a markdown file with
+// frontmatter identifying the host, command and time it was captured.
+func SaveAsNote(r Result) (string, error) {
+	if err := os.MkdirAll(notesDir, 0o755); err != nil {
+		return "", fmt.Errorf("create notes dir: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.md", sanitize(r.Host), r.When.Format("20060102T150405"))
+	path := filepath.Join(notesDir, name)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "---\nhost: %s\ncommand: %s\ntime: %s\n---\n\n", r.Host, r.Command, r.When.Format(time.RFC3339))
+	buf.WriteString(r.Output)
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return "", fmt.Errorf("write note %q: %w", path, err)
+	}
+	return path, nil
+}
+
+// ExportToPaste POSTs the output to the configured paste/gist service and
+// returns the shareable URL it responds with.
+func ExportToPaste(cfg PasteConfig, r Result) (string, error) {
+	if cfg.Endpoint == "" {
+		return "", fmt.Errorf("no paste service configured")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, cfg.Endpoint, strings.NewReader(r.Output))
+	if err != nil {
+		return "", fmt.Errorf("build paste request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	if cfg.APIToken != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.APIToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("send to paste service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("paste service returned %s", resp.Status)
+	}
+
+	url := resp.Header.Get("Location")
+	if url == "" {
+		return "", fmt.Errorf("paste service did not return a Location header")
+	}
+	return url, nil
+}
+
+func sanitize(s string) string {
+	s = strings.ReplaceAll(s, string(filepath.Separator), "_")
+	return strings.ReplaceAll(s, " ", "_")
+}
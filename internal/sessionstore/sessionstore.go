@@ -0,0 +1,63 @@
+// Package sessionstore provides a concurrency-safe, per-App registry of
+// active sessions, replacing ad hoc locking around a shared map as the
+// number of subsystems touching sessions grows.
+package sessionstore
+
+import (
+	"fmt"
+	"sync"
+
+	"sshapp/internal/sshsession"
+)
+
+// Store is a concurrency-safe registry of active sessions, keyed by
+// session ID. Each App owns exactly one Store; there is no shared
+// package-level state.
+type Store struct {
+	mu       sync.RWMutex
+	sessions map[string]*sshsession.Session
+}
+
+// New returns an empty Store.
+func New() *Store {
+	return &Store{sessions: make(map[string]*sshsession.Session)}
+}
+
+// Add registers sess under its own ID.
+func (s *Store) Add(sess *sshsession.Session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[sess.ID] = sess
+}
+
+// Remove drops a session from the registry.
+func (s *Store) Remove(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, sessionID)
+}
+
+// Get returns the session with the given ID, or an error if it is not
+// registered.
+func (s *Store) Get(sessionID string) (*sshsession.Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sess, ok := s.sessions[sessionID]
+	if !ok {
+		return nil, fmt.Errorf("no active session %q", sessionID)
+	}
+	return sess, nil
+}
+
+// List returns every currently registered session.
+func (s *Store) List() []*sshsession.Session {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]*sshsession.Session, 0, len(s.sessions))
+	for _, sess := range s.sessions {
+		out = append(out, sess)
+	}
+	return out
+}
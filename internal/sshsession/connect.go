@@ -0,0 +1,77 @@
+package sshsession
+
+import (
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Open dials an interactive PTY shell over client, wraps it as a
+// Session keyed by id, and starts the goroutine that pumps its output
+// into the registered OnOutput callback (and sudo-prompt detection)
+// until the session is closed. id is normally the owning profile's
+// name, matching how every sessionID-taking caller already looks
+// sessions up. Open takes ownership of client: closing the returned
+// Session closes it too.
+func Open(id, host string, client *ssh.Client, opts PTYOptions, cols, rows int) (*Session, error) {
+	sshSess, err := client.NewSession()
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("session %s: open session: %w", id, err)
+	}
+
+	if err := RequestPTY(sshSess, opts, cols, rows); err != nil {
+		sshSess.Close()
+		client.Close()
+		return nil, fmt.Errorf("session %s: %w", id, err)
+	}
+
+	stdin, err := sshSess.StdinPipe()
+	if err != nil {
+		sshSess.Close()
+		client.Close()
+		return nil, fmt.Errorf("session %s: open stdin pipe: %w", id, err)
+	}
+
+	stdout, err := sshSess.StdoutPipe()
+	if err != nil {
+		sshSess.Close()
+		client.Close()
+		return nil, fmt.Errorf("session %s: open stdout pipe: %w", id, err)
+	}
+
+	if err := sshSess.Shell(); err != nil {
+		sshSess.Close()
+		client.Close()
+		return nil, fmt.Errorf("session %s: start shell: %w", id, err)
+	}
+
+	s := &Session{
+		ID:     id,
+		Host:   host,
+		client: client,
+		sess:   sshSess,
+		stdin:  stdin,
+	}
+
+	go s.pump(stdout)
+	return s, nil
+}
+
+// pump reads r (the session's combined PTY output) until it's closed,
+// feeding every chunk through handleOutput.
+func (s *Session) pump(r io.Reader) {
+	buf := make([]byte, 4096)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			chunk := make([]byte, n)
+			copy(chunk, buf[:n])
+			s.handleOutput(chunk)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
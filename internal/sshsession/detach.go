@@ -0,0 +1,34 @@
+package sshsession
+
+import "fmt"
+
+// Detach marks a session as backgrounded: its remote process keeps
+// running (started under nohup/tmux by the caller) but the session
+// stops forwarding output to the frontend until Reattach is called.
+func (s *Session) Detach() {
+	s.mu.Lock()
+	s.detached = true
+	s.mu.Unlock()
+}
+
+// Reattach resumes forwarding output for a previously detached session
+// and returns the session's rolling output tail: the last 4096 bytes the
+// PTY produced, which can include output from before Detach. Output
+// older than that tail is not kept while detached.
+func (s *Session) Reattach() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.detached {
+		return "", fmt.Errorf("session %s: not detached", s.ID)
+	}
+	s.detached = false
+	return s.buf.String(), nil
+}
+
+// IsDetached reports whether the session is currently backgrounded.
+func (s *Session) IsDetached() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.detached
+}
@@ -0,0 +1,72 @@
+package sshsession
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Run executes command as a one-shot remote exec (distinct from the
+// session's interactive PTY) and returns its combined stdout+stderr. It
+// satisfies pipeline.Runner.
+//
+// If SetShellOptions set a preferred shell, command runs as
+// `<shell> -lc "<command>"` instead of going straight to the SSH
+// server's own command interpreter, so profiles whose account defaults
+// to a shell that mishandles the app's scripts (e.g. a login shell set
+// to a restricted or non-POSIX shell) can opt into one that doesn't.
+// Any environment variables set via SetShellOptions are exported before
+// command runs, on a best-effort basis (most sshd configs only forward
+// variables listed in AcceptEnv, so sess.Setenv often silently does
+// nothing — exporting them in the wrapped command line guarantees they
+// reach the process either way).
+func (s *Session) Run(command string) (string, error) {
+	if s.client == nil {
+		return "", fmt.Errorf("session %s: not connected", s.ID)
+	}
+
+	sess, err := s.client.NewSession()
+	if err != nil {
+		return "", fmt.Errorf("session %s: open exec session: %w", s.ID, err)
+	}
+	defer sess.Close()
+
+	s.mu.Lock()
+	shell, env := s.shell, s.env
+	s.mu.Unlock()
+
+	for k, v := range env {
+		sess.Setenv(k, v)
+	}
+	command = wrapCommand(command, shell, env)
+
+	var out bytes.Buffer
+	sess.Stdout = &out
+	sess.Stderr = &out
+
+	if err := sess.Run(command); err != nil {
+		return out.String(), fmt.Errorf("session %s: run %q: %w", s.ID, command, err)
+	}
+	return out.String(), nil
+}
+
+// wrapCommand exports env (if any) and, if shell is set, runs command
+// through it as a login shell so profile-specific shell quirks (aliases,
+// PATH set up in .profile/.zshrc) apply the same way they would in an
+// interactive session.
+func wrapCommand(command, shell string, env map[string]string) string {
+	var b strings.Builder
+	for k, v := range env {
+		fmt.Fprintf(&b, "export %s=%s; ", k, shellQuoteEnv(v))
+	}
+	b.WriteString(command)
+
+	if shell == "" {
+		return b.String()
+	}
+	return fmt.Sprintf("%s -lc %s", shell, shellQuoteEnv(b.String()))
+}
+
+func shellQuoteEnv(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,52 @@
+package sshsession
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// PTYOptions customizes the pseudo-terminal requested for a session,
+// set per-profile so legacy hosts that need e.g. vt100 or a latin-1
+// locale render correctly instead of getting this app's defaults.
+type PTYOptions struct {
+	TermType        string // e.g. "xterm-256color", "vt100"; empty uses DefaultPTYOptions
+	Encoding        string // e.g. "UTF-8", "ISO-8859-1"; informs Locale when set but Locale isn't
+	ScrollbackLines int    // 0 uses DefaultPTYOptions
+	Locale          string // e.g. "en_US.UTF-8"; sent as LANG/LC_ALL
+}
+
+// DefaultPTYOptions is what a session uses when nothing overrides it.
+var DefaultPTYOptions = PTYOptions{TermType: "xterm-256color", ScrollbackLines: 10000}
+
+func (o PTYOptions) normalize() PTYOptions {
+	if o.TermType == "" {
+		o.TermType = DefaultPTYOptions.TermType
+	}
+	if o.ScrollbackLines == 0 {
+		o.ScrollbackLines = DefaultPTYOptions.ScrollbackLines
+	}
+	return o
+}
+
+// RequestPTY requests a pseudo-terminal on sess sized cols x rows,
+// applying opts' term type and locale. It must be called (and any
+// Setenv calls take effect) before sess.Shell()/sess.Start().
+// Open calls it for every session it establishes, with the PTYOptions
+// its caller passes in.
+func RequestPTY(sess *ssh.Session, opts PTYOptions, cols, rows int) error {
+	opts = opts.normalize()
+
+	if opts.Locale != "" {
+		// Best-effort: most sshd configs only forward variables listed
+		// in AcceptEnv, so this often silently does nothing, but it's
+		// the same thing OpenSSH's own client does.
+		sess.Setenv("LANG", opts.Locale)
+		sess.Setenv("LC_ALL", opts.Locale)
+	}
+
+	if err := sess.RequestPty(opts.TermType, rows, cols, ssh.TerminalModes{}); err != nil {
+		return fmt.Errorf("request pty (term=%s): %w", opts.TermType, err)
+	}
+	return nil
+}
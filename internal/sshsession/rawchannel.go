@@ -0,0 +1,31 @@
+package sshsession
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// OpenRawChannel opens a raw SSH channel of the given type with the
+// given request payload, bypassing every higher-level abstraction in
+// this package. It exists as an escape hatch for plugins that need
+// direct protocol access (custom subsystems, non-exec channel types)
+// this package doesn't otherwise expose.
+func (s *Session) OpenRawChannel(channelType string, extraData []byte) (ssh.Channel, <-chan *ssh.Request, error) {
+	if s.client == nil {
+		return nil, nil, fmt.Errorf("session %s: not connected", s.ID)
+	}
+
+	ch, reqs, err := s.client.OpenChannel(channelType, extraData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("session %s: open raw channel %q: %w", s.ID, channelType, err)
+	}
+	return ch, reqs, nil
+}
+
+// UnderlyingClient exposes the session's *ssh.Client directly, for
+// plugin code that needs capabilities (custom Dial, SendRequest) this
+// package has no wrapper for.
+func (s *Session) UnderlyingClient() *ssh.Client {
+	return s.client
+}
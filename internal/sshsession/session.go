@@ -0,0 +1,129 @@
+// Package sshsession manages interactive PTY-backed SSH sessions used to
+// drive the terminal UI.
+package sshsession
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// OutputEvent is emitted to the frontend for every chunk of output a
+// session produces.
+type OutputEvent struct {
+	SessionID string
+	Data      string
+}
+
+// Session wraps a single interactive SSH PTY session.
+type Session struct {
+	ID     string
+	Host   string
+	client *ssh.Client
+	sess   *ssh.Session
+	stdin  io.WriteCloser
+
+	mu  sync.Mutex
+	buf bytes.Buffer
+
+	onOutput         func(OutputEvent)
+	onSudoPrompt     func(SudoPromptEvent)
+	sudoPromptActive bool
+	detached         bool
+	currentUser      string
+	shell            string
+	env              map[string]string
+
+	sftp sftpCache
+
+	tracer Tracer
+}
+
+// OnOutput registers the callback invoked for every chunk of output the
+// session's remote PTY produces, so the frontend terminal can render it
+// as it arrives.
+func (s *Session) OnOutput(fn func(OutputEvent)) {
+	s.mu.Lock()
+	s.onOutput = fn
+	s.mu.Unlock()
+}
+
+// Write sends raw bytes to the remote PTY's stdin, as if typed by the
+// user.
+func (s *Session) Write(data []byte) (int, error) {
+	if s.stdin == nil {
+		return 0, fmt.Errorf("session %s: no stdin pipe", s.ID)
+	}
+	return s.stdin.Write(data)
+}
+
+// WriteLine is a convenience wrapper for Write that appends a newline.
+func (s *Session) WriteLine(line string) error {
+	_, err := s.Write([]byte(line + "\n"))
+	return err
+}
+
+// writeLineBytes is like WriteLine but takes the line as a byte slice
+// it owns and zeroes once sent, for callers (SupplySudoPassword) that
+// need the plaintext wiped as soon as possible instead of living on in
+// an extra string-concatenation copy until the GC gets to it.
+func (s *Session) writeLineBytes(line []byte) error {
+	buf := make([]byte, len(line)+1)
+	copy(buf, line)
+	buf[len(line)] = '\n'
+	defer zero(buf)
+
+	_, err := s.Write(buf)
+	return err
+}
+
+// Close terminates the session and the underlying SSH client, closing
+// its cached SFTP client (if remotefs ever opened one) along the way
+// so it doesn't outlive the connection it was opened on.
+func (s *Session) Close() error {
+	s.InvalidateSFTPClient()
+
+	var errs []error
+	if s.sess != nil {
+		if err := s.sess.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if s.client != nil {
+		if err := s.client.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("closing session %s: %v", s.ID, errs)
+	}
+	return nil
+}
+
+// handleOutput is called for every chunk of data read from the remote
+// PTY. It updates the rolling buffer used for prompt detection and
+// forwards the chunk to the registered output callback.
+func (s *Session) handleOutput(data []byte) {
+	s.mu.Lock()
+	s.buf.Write(data)
+	// Cap the rolling buffer so long-running sessions don't grow it
+	// without bound; only the tail is needed for prompt detection.
+	if s.buf.Len() > 4096 {
+		trimmed := s.buf.Bytes()[s.buf.Len()-4096:]
+		s.buf.Reset()
+		s.buf.Write(trimmed)
+	}
+	tail := s.buf.String()
+	detached := s.detached
+	onOutput := s.onOutput
+	s.mu.Unlock()
+
+	if onOutput != nil && !detached {
+		onOutput(OutputEvent{SessionID: s.ID, Data: string(data)})
+	}
+
+	checkSudoPrompt(s, tail)
+}
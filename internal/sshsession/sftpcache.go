@@ -0,0 +1,53 @@
+package sshsession
+
+import (
+	"sync"
+
+	"github.com/pkg/sftp"
+)
+
+// sftpCache holds the session's lazily-created SFTP client, so
+// internal/remotefs can reuse one client per session instead of paying
+// for a fresh SFTP subsystem handshake on every file operation. It
+// lives on the Session (rather than a package-level map keyed by
+// *Session, which is what internal/sessionstore replaced for the
+// session registry itself) so the client is freed automatically when
+// the session is.
+type sftpCache struct {
+	mu     sync.Mutex
+	client *sftp.Client
+}
+
+// GetOrCreateSFTPClient returns the session's cached SFTP client,
+// creating one via newClient on first use. newClient runs with the
+// cache locked, so two callers racing to populate an empty cache still
+// only ever create one client.
+func (s *Session) GetOrCreateSFTPClient(newClient func() (*sftp.Client, error)) (*sftp.Client, error) {
+	s.sftp.mu.Lock()
+	defer s.sftp.mu.Unlock()
+
+	if s.sftp.client != nil {
+		return s.sftp.client, nil
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+	s.sftp.client = c
+	return c, nil
+}
+
+// InvalidateSFTPClient discards and closes the session's cached SFTP
+// client, if any, so the next GetOrCreateSFTPClient call creates a
+// fresh one.
+func (s *Session) InvalidateSFTPClient() {
+	s.sftp.mu.Lock()
+	c := s.sftp.client
+	s.sftp.client = nil
+	s.sftp.mu.Unlock()
+
+	if c != nil {
+		c.Close()
+	}
+}
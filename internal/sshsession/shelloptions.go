@@ -0,0 +1,12 @@
+package sshsession
+
+// SetShellOptions records the preferred shell (e.g. "bash", "zsh",
+// "fish", "sh") and environment variables Run should use for this
+// session, normally taken from the owning profile. An empty shell
+// leaves Run's default (the remote account's login shell) untouched.
+func (s *Session) SetShellOptions(shell string, env map[string]string) {
+	s.mu.Lock()
+	s.shell = shell
+	s.env = env
+	s.mu.Unlock()
+}
@@ -0,0 +1,83 @@
+package sshsession
+
+import "strings"
+
+// sudoPromptMarkers are the substrings that indicate the remote shell is
+// waiting on a sudo password. "[sudo] password for" is the common Linux
+// phrasing; "a password is required" covers the "sudo: a password is
+// required" variant some distros use.
+var sudoPromptMarkers = []string{
+	"[sudo] password for",
+	"sudo: a password is required",
+	"Password:",
+}
+
+// SudoPromptEvent is emitted when a session's output looks like a sudo
+// password prompt, so the frontend can show a secure (non-echoing) input.
+type SudoPromptEvent struct {
+	SessionID string
+	Prompt    string
+}
+
+// OnSudoPrompt registers the callback invoked when the session detects a
+// sudo password prompt in its output.
+func (s *Session) OnSudoPrompt(fn func(SudoPromptEvent)) {
+	s.mu.Lock()
+	s.onSudoPrompt = fn
+	s.mu.Unlock()
+}
+
+// checkSudoPrompt inspects the tail of a session's output buffer for a
+// sudo password prompt and fires the registered callback at most once per
+// prompt.
+func checkSudoPrompt(s *Session, tail string) {
+	for _, marker := range sudoPromptMarkers {
+		if !strings.Contains(tail, marker) {
+			continue
+		}
+
+		s.mu.Lock()
+		already := s.sudoPromptActive
+		s.sudoPromptActive = true
+		cb := s.onSudoPrompt
+		s.mu.Unlock()
+
+		if already || cb == nil {
+			return
+		}
+		cb(SudoPromptEvent{SessionID: s.ID, Prompt: marker})
+		return
+	}
+
+	s.mu.Lock()
+	s.sudoPromptActive = false
+	s.mu.Unlock()
+}
+
+// SupplySudoPassword writes password to the session's stdin followed by
+// a newline, without echoing it back into the session's own output
+// buffer. It zeroes every byte copy it makes along the way as soon as
+// it's been sent, but this is best-effort, not a guarantee: Go gives no
+// way to wipe the caller's own password string, so its backing bytes
+// (and any copy encoding/json made while unmarshaling it off the wire)
+// remain in memory until the garbage collector reclaims them.
+func (s *Session) SupplySudoPassword(password string) error {
+	b := []byte(password)
+	defer zero(b)
+
+	if err := s.writeLineBytes(b); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.sudoPromptActive = false
+	s.mu.Unlock()
+	return nil
+}
+
+// zero overwrites b's bytes in place.
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,31 @@
+package sshsession
+
+import "fmt"
+
+// SwitchUser runs `su - <user>` in the session's interactive PTY,
+// supplying password (if given) when prompted, so the rest of the
+// session continues as that user.
+func (s *Session) SwitchUser(user, password string) error {
+	if err := s.WriteLine(fmt.Sprintf("su - %s", user)); err != nil {
+		return fmt.Errorf("session %s: switch user to %q: %w", s.ID, user, err)
+	}
+
+	if password != "" {
+		if err := s.SupplySudoPassword(password); err != nil {
+			return fmt.Errorf("session %s: supply password for %q: %w", s.ID, user, err)
+		}
+	}
+
+	s.mu.Lock()
+	s.currentUser = user
+	s.mu.Unlock()
+	return nil
+}
+
+// CurrentUser returns the user the session last switched to via
+// SwitchUser, or "" if it has not switched.
+func (s *Session) CurrentUser() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.currentUser
+}
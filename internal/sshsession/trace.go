@@ -0,0 +1,72 @@
+package sshsession
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TraceEvent is one event recorded in a session's trace while tracing is
+// enabled. The only event this package logs itself is the one
+// EnableTrace records; key exchange, channel open/close and global
+// requests are not captured.
+type TraceEvent struct {
+	When   time.Time
+	Stage  string
+	Detail string
+}
+
+// Tracer accumulates TraceEvents for a single session.
+type Tracer struct {
+	mu      sync.Mutex
+	enabled bool
+	events  []TraceEvent
+}
+
+// Enable turns on tracing; subsequent calls to Log record events.
+func (t *Tracer) Enable() {
+	t.mu.Lock()
+	t.enabled = true
+	t.mu.Unlock()
+}
+
+// Disable turns off tracing without discarding previously recorded
+// events.
+func (t *Tracer) Disable() {
+	t.mu.Lock()
+	t.enabled = false
+	t.mu.Unlock()
+}
+
+// Log records a trace event if tracing is enabled.
+func (t *Tracer) Log(stage, detail string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.enabled {
+		return
+	}
+	t.events = append(t.events, TraceEvent{When: time.Now(), Stage: stage, Detail: detail})
+}
+
+// Events returns a copy of the recorded trace events.
+func (t *Tracer) Events() []TraceEvent {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]TraceEvent(nil), t.events...)
+}
+
+// EnableTrace turns on low-level SSH debug tracing for this session.
+func (s *Session) EnableTrace() {
+	s.tracer.Enable()
+	s.tracer.Log("trace", fmt.Sprintf("tracing enabled for session %s", s.ID))
+}
+
+// DisableTrace turns off tracing for this session.
+func (s *Session) DisableTrace() {
+	s.tracer.Disable()
+}
+
+// TraceEvents returns the trace events recorded for this session so far.
+func (s *Session) TraceEvents() []TraceEvent {
+	return s.tracer.Events()
+}
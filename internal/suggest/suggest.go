@@ -0,0 +1,77 @@
+// Package suggest ranks autocomplete candidates for the terminal input
+// box, merging command history, saved commands, and the remote shell's
+// PATH executables.
+package suggest
+
+import (
+	"strings"
+	"sync"
+
+	"sshapp/internal/fuzzy"
+)
+
+// Runner executes a one-shot command on a remote session, matching
+// sshsession.Session's Run method.
+type Runner interface {
+	Run(command string) (string, error)
+}
+
+// Cache holds each host's executable list, populated lazily on first use
+// since collecting it requires a round-trip to the remote shell.
+type Cache struct {
+	mu    sync.Mutex
+	execs map[string][]string
+}
+
+// NewCache returns an empty executable cache.
+func NewCache() *Cache {
+	return &Cache{execs: make(map[string][]string)}
+}
+
+// Executables returns host's PATH executables, running `compgen -c` over
+// runner the first time host is seen and caching the result thereafter.
+func (c *Cache) Executables(host string, runner Runner) ([]string, error) {
+	c.mu.Lock()
+	if cached, ok := c.execs[host]; ok {
+		c.mu.Unlock()
+		return cached, nil
+	}
+	c.mu.Unlock()
+
+	output, err := runner.Run("compgen -c | sort -u")
+	if err != nil {
+		return nil, err
+	}
+	execs := strings.Split(strings.TrimSpace(output), "\n")
+
+	c.mu.Lock()
+	c.execs[host] = execs
+	c.mu.Unlock()
+	return execs, nil
+}
+
+// Invalidate forgets host's cached executables, forcing the next
+// Executables call to re-collect them.
+func (c *Cache) Invalidate(host string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.execs, host)
+}
+
+// Merge ranks prefix against history, saved commands, and executables
+// (in that priority order, deduplicated), returning the best completions
+// first.
+func Merge(prefix string, history, saved, execs []string) []fuzzy.Result {
+	seen := make(map[string]bool)
+	var candidates []string
+	for _, group := range [][]string{history, saved, execs} {
+		for _, c := range group {
+			if c == "" || seen[c] {
+				continue
+			}
+			seen[c] = true
+			candidates = append(candidates, c)
+		}
+	}
+	return fuzzy.Search(prefix, candidates)
+}
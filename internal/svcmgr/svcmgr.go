@@ -0,0 +1,156 @@
+// Package svcmgr manages systemd units on a connected host: listing,
+// status, journal excerpts, and start/stop/restart/enable/disable, with
+// optional sudo for the operations that need root, so the UI can offer
+// a services panel per host instead of hand-typing systemctl.
+package svcmgr
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Unit is a single systemd unit, as reported by `systemctl list-units`.
+type Unit struct {
+	Name        string
+	LoadState   string
+	ActiveState string
+	SubState    string
+	Description string
+}
+
+// Runner executes a single command on a connected session and returns
+// its combined output, satisfied by *sshsession.Session.
+type Runner interface {
+	Run(command string) (string, error)
+}
+
+// ClientProvider exposes the *ssh.Client a privileged action execs
+// over, satisfied by *sshsession.Session. Unlike Runner, it's needed
+// (rather than just useful) for Apply, since piping a sudo password in
+// requires a raw exec session with its own Stdin.
+type ClientProvider interface {
+	UnderlyingClient() *ssh.Client
+}
+
+const listUnitsCommand = `systemctl list-units --type=service --all --no-legend --no-pager --plain`
+
+// ListUnits returns every systemd service unit on r's host, loaded or
+// not, active or not.
+func ListUnits(r Runner) ([]Unit, error) {
+	out, err := r.Run(listUnitsCommand)
+	if err != nil {
+		return nil, fmt.Errorf("list units: %w", err)
+	}
+
+	var units []Unit
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		u, ok := parseUnitLine(line)
+		if !ok {
+			continue
+		}
+		units = append(units, u)
+	}
+	return units, nil
+}
+
+func parseUnitLine(line string) (Unit, bool) {
+	// UNIT LOAD ACTIVE SUB DESCRIPTION..., columns separated by
+	// whitespace, description free-form and possibly multi-word.
+	fields := strings.Fields(line)
+	if len(fields) < 4 {
+		return Unit{}, false
+	}
+	return Unit{
+		Name:        fields[0],
+		LoadState:   fields[1],
+		ActiveState: fields[2],
+		SubState:    fields[3],
+		Description: strings.Join(fields[4:], " "),
+	}, true
+}
+
+// Status returns `systemctl status`'s output for unit.
+func Status(r Runner, unit string) (string, error) {
+	out, err := r.Run(fmt.Sprintf("systemctl status %s --no-pager", shellQuoteArg(unit)))
+	if err != nil {
+		// systemctl status exits non-zero for a stopped-but-valid unit,
+		// so its output is still useful even on error.
+		return out, fmt.Errorf("status %s: %w", unit, err)
+	}
+	return out, nil
+}
+
+// JournalExcerpt returns the last lines entries of unit's journal.
+func JournalExcerpt(r Runner, unit string, lines int) (string, error) {
+	if lines <= 0 {
+		lines = 50
+	}
+	out, err := r.Run(fmt.Sprintf("journalctl -u %s -n %s --no-pager", shellQuoteArg(unit), strconv.Itoa(lines)))
+	if err != nil {
+		return "", fmt.Errorf("journal for %s: %w", unit, err)
+	}
+	return out, nil
+}
+
+// Action is a systemctl verb Apply is allowed to run.
+type Action string
+
+const (
+	Start   Action = "start"
+	Stop    Action = "stop"
+	Restart Action = "restart"
+	Enable  Action = "enable"
+	Disable Action = "disable"
+)
+
+func (a Action) valid() bool {
+	switch a {
+	case Start, Stop, Restart, Enable, Disable:
+		return true
+	}
+	return false
+}
+
+// Apply runs `systemctl <action> <unit>` on sess's host via sudo,
+// piping sudoPassword in on stdin rather than putting it on the command
+// line. sudoPassword may be empty for hosts configured with passwordless
+// sudo for systemctl (sudo -S simply doesn't read stdin in that case).
+func Apply(sess ClientProvider, unit string, action Action, sudoPassword string) error {
+	if !action.valid() {
+		return fmt.Errorf("invalid action %q", action)
+	}
+
+	client := sess.UnderlyingClient()
+	if client == nil {
+		return fmt.Errorf("svcmgr: session not connected")
+	}
+
+	s, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("%s %s: open exec session: %w", action, unit, err)
+	}
+	defer s.Close()
+
+	s.Stdin = strings.NewReader(sudoPassword + "\n")
+	var out bytes.Buffer
+	s.Stdout = &out
+	s.Stderr = &out
+
+	cmd := fmt.Sprintf("sudo -S systemctl %s %s", action, shellQuoteArg(unit))
+	if err := s.Run(cmd); err != nil {
+		return fmt.Errorf("%s %s: %w: %s", action, unit, err, strings.TrimSpace(out.String()))
+	}
+	return nil
+}
+
+func shellQuoteArg(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,46 @@
+// Package template expands saved command templates with {{var}}
+// placeholders into runnable commands.
+package template
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var placeholder = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// Render substitutes every {{var}} placeholder in tmpl with vars[var],
+// returning an error naming the first placeholder with no matching
+// value.
+func Render(tmpl string, vars map[string]string) (string, error) {
+	var missing string
+
+	out := placeholder.ReplaceAllStringFunc(tmpl, func(match string) string {
+		name := placeholder.FindStringSubmatch(match)[1]
+		value, ok := vars[name]
+		if !ok && missing == "" {
+			missing = name
+		}
+		return value
+	})
+
+	if missing != "" {
+		return "", fmt.Errorf("missing value for template variable %q", missing)
+	}
+	return out, nil
+}
+
+// Variables returns the distinct placeholder names referenced by tmpl,
+// in order of first appearance.
+func Variables(tmpl string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, m := range placeholder.FindAllStringSubmatch(tmpl, -1) {
+		name := m[1]
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,65 @@
+package textsync
+
+import "strings"
+
+// Op is one step of a line-level diff: keep a line from the original,
+// or insert a new line.
+type Op struct {
+	Insert bool
+	Line   string
+}
+
+// Diff computes a minimal line-level edit script turning oldText into
+// newText, using a straightforward longest-common-subsequence diff.
+// It is not intended for huge files, only the kind of config/notes text
+// this app round-trips.
+func Diff(oldText, newText string) []Op {
+	oldLines := strings.Split(oldText, "\n")
+	newLines := strings.Split(newText, "\n")
+
+	n, m := len(oldLines), len(newLines)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []Op
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case oldLines[i] == newLines[j]:
+			ops = append(ops, Op{Line: oldLines[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			ops = append(ops, Op{Insert: true, Line: newLines[j]})
+			j++
+		}
+	}
+	for ; j < m; j++ {
+		ops = append(ops, Op{Insert: true, Line: newLines[j]})
+	}
+	return ops
+}
+
+// Apply reconstructs the text described by a diff's Ops.
+func Apply(ops []Op) string {
+	lines := make([]string, len(ops))
+	for i, op := range ops {
+		lines[i] = op.Line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,100 @@
+package transfer
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// CompressedSource wraps a Source, gzipping its bytes as they're read so
+// a transfer moves less data over a slow link. The receiving end must
+// decompress with CompressedSink (or an equivalent `gunzip`) to get the
+// original bytes back.
+type CompressedSource struct {
+	Source
+}
+
+// Open implements Source, returning a reader that gzips Source's bytes
+// on the fly via an in-memory pipe.
+func (c CompressedSource) Open() (io.ReadCloser, error) {
+	r, err := c.Source.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	gz := gzip.NewWriter(pw)
+
+	go func() {
+		_, err := io.Copy(gz, r)
+		r.Close()
+		if err != nil {
+			pw.CloseWithError(fmt.Errorf("compress transfer source: %w", err))
+			return
+		}
+		if err := gz.Close(); err != nil {
+			pw.CloseWithError(fmt.Errorf("flush gzip writer: %w", err))
+			return
+		}
+		pw.Close()
+	}()
+
+	return pr, nil
+}
+
+// CompressedSink wraps a Sink, gunzipping incoming bytes before writing
+// them, the counterpart to CompressedSource.
+type CompressedSink struct {
+	Sink
+}
+
+// Create implements Sink, returning a writer that gunzips bytes before
+// passing them to Sink.
+func (c CompressedSink) Create() (io.WriteCloser, error) {
+	w, err := c.Sink.Create()
+	if err != nil {
+		return nil, err
+	}
+	return &gunzipWriter{dst: w}, nil
+}
+
+// gunzipWriter pipes everything written to it to a goroutine that
+// gunzips it into dst as it arrives, since gzip's decoder reads from a
+// Reader rather than accepting writes; Close waits for that goroutine
+// to finish and then closes dst.
+type gunzipWriter struct {
+	dst  io.WriteCloser
+	pr   *io.PipeReader
+	pw   *io.PipeWriter
+	done chan error
+}
+
+func (g *gunzipWriter) Write(p []byte) (int, error) {
+	if g.pw == nil {
+		g.pr, g.pw = io.Pipe()
+		g.done = make(chan error, 1)
+		go func() {
+			gz, err := gzip.NewReader(g.pr)
+			if err != nil {
+				g.pr.CloseWithError(err)
+				g.done <- fmt.Errorf("open gzip reader: %w", err)
+				return
+			}
+			_, err = io.Copy(g.dst, gz)
+			g.done <- err
+		}()
+	}
+	return g.pw.Write(p)
+}
+
+func (g *gunzipWriter) Close() error {
+	if g.pw == nil {
+		return g.dst.Close()
+	}
+	g.pw.Close()
+	err := <-g.done
+	if closeErr := g.dst.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
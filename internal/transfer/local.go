@@ -0,0 +1,91 @@
+package transfer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalFile is a Source and Sink backed by a path on the local
+// filesystem.
+type LocalFile struct {
+	Path string
+}
+
+// Open implements Source.
+func (l LocalFile) Open() (io.ReadCloser, error) {
+	return os.Open(l.Path)
+}
+
+// Create implements Sink.
+func (l LocalFile) Create() (io.WriteCloser, error) {
+	return os.Create(l.Path)
+}
+
+// Size implements Sizer.
+func (l LocalFile) Size() (int64, error) {
+	info, err := os.Stat(l.Path)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// OpenAt implements OffsetSource, seeking past the bytes already copied
+// so a resumed transfer doesn't re-read them.
+func (l LocalFile) OpenAt(offset int64) (io.ReadCloser, error) {
+	f, err := os.Open(l.Path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// CreateAt implements OffsetSink, opening the destination without
+// truncating it and seeking to offset so a resumed transfer continues
+// writing after the bytes already written.
+func (l LocalFile) CreateAt(offset int64) (io.WriteCloser, error) {
+	f, err := os.OpenFile(l.Path, os.O_WRONLY|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// Exists implements Exister.
+func (l LocalFile) Exists() (bool, error) {
+	_, err := os.Stat(l.Path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// NextAvailable implements Renamer, trying "name (1).ext", "name (2).ext",
+// and so on until it finds a path that doesn't exist yet.
+func (l LocalFile) NextAvailable() (Sink, error) {
+	dir := filepath.Dir(l.Path)
+	ext := filepath.Ext(l.Path)
+	base := strings.TrimSuffix(filepath.Base(l.Path), ext)
+
+	for i := 1; i < 1000; i++ {
+		candidate := filepath.Join(dir, fmt.Sprintf("%s (%d)%s", base, i, ext))
+		if _, err := os.Stat(candidate); os.IsNotExist(err) {
+			return LocalFile{Path: candidate}, nil
+		}
+	}
+	return nil, fmt.Errorf("find available name for %q: too many conflicts", l.Path)
+}
@@ -0,0 +1,441 @@
+package transfer
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// State is a transfer's position in its lifecycle.
+type State string
+
+const (
+	Queued    State = "queued"
+	Running   State = "running"
+	Paused    State = "paused"
+	Completed State = "completed"
+	Failed    State = "failed"
+	Cancelled State = "cancelled"
+	Skipped   State = "skipped"
+)
+
+// ConflictPolicy controls what a transfer does when its destination
+// already exists.
+type ConflictPolicy string
+
+const (
+	Overwrite ConflictPolicy = "overwrite" // the long-standing default: just write over it
+	Skip      ConflictPolicy = "skip"
+	Rename    ConflictPolicy = "rename"
+)
+
+// Exister is optionally implemented by a Sink to report whether its
+// destination already exists, so Manager can apply a ConflictPolicy
+// before overwriting something the caller didn't expect to.
+type Exister interface {
+	Exists() (bool, error)
+}
+
+// Renamer is optionally implemented by a Sink that supports the Rename
+// conflict policy, returning a Sink for an alternate destination that
+// doesn't exist yet (e.g. "file (1).txt").
+type Renamer interface {
+	NextAvailable() (Sink, error)
+}
+
+// Sizer is optionally implemented by a Source to report how many bytes
+// it holds up front, so Progress can report a real total and percent
+// rather than guessing.
+type Sizer interface {
+	Size() (int64, error)
+}
+
+// OffsetSource is optionally implemented by a Source that can resume
+// reading partway through, rather than always starting at byte zero.
+type OffsetSource interface {
+	OpenAt(offset int64) (io.ReadCloser, error)
+}
+
+// OffsetSink is optionally implemented by a Sink that can resume writing
+// partway through (typically by opening for append), rather than always
+// truncating and starting over.
+type OffsetSink interface {
+	CreateAt(offset int64) (io.WriteCloser, error)
+}
+
+// Progress is a point-in-time snapshot of one queued transfer, suitable
+// for streaming to the frontend as an event.
+type Progress struct {
+	ID          string
+	Direction   Direction
+	State       State
+	BytesDone   int64
+	TotalBytes  int64 // 0 if the source didn't implement Sizer
+	Percent     float64
+	BytesPerSec float64
+	Err         string
+}
+
+const chunkSize = 32 * 1024
+
+// job is one queued transfer and the state Manager needs to
+// pause/resume/cancel it mid-copy.
+type job struct {
+	id        string
+	direction Direction
+	src       Source
+	dst       Sink
+	policy    ConflictPolicy
+
+	verify func() error
+
+	mu         sync.Mutex
+	cond       *sync.Cond
+	state      State
+	bytesDone  int64
+	totalBytes int64
+	startedAt  time.Time
+	cancelled  bool
+	err        error
+}
+
+func (j *job) progress() Progress {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	p := Progress{
+		ID:         j.id,
+		Direction:  j.direction,
+		State:      j.state,
+		BytesDone:  j.bytesDone,
+		TotalBytes: j.totalBytes,
+	}
+	if j.totalBytes > 0 {
+		p.Percent = 100 * float64(j.bytesDone) / float64(j.totalBytes)
+	}
+	if elapsed := time.Since(j.startedAt).Seconds(); !j.startedAt.IsZero() && elapsed > 0 {
+		p.BytesPerSec = float64(j.bytesDone) / elapsed
+	}
+	if j.err != nil {
+		p.Err = j.err.Error()
+	}
+	return p
+}
+
+// Manager queues transfers, runs up to concurrency of them at once, and
+// lets callers pause, resume, or cancel any transfer by ID while it's in
+// flight.
+type Manager struct {
+	mu         sync.Mutex
+	sem        chan struct{}
+	jobs       map[string]*job
+	order      []string
+	nextID     int
+	onProgress func(Progress)
+}
+
+// NewManager returns a transfer queue that runs at most concurrency
+// transfers at once, calling onProgress (if non-nil) whenever a
+// transfer's progress changes.
+func NewManager(concurrency int, onProgress func(Progress)) *Manager {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &Manager{
+		sem:        make(chan struct{}, concurrency),
+		jobs:       make(map[string]*job),
+		onProgress: onProgress,
+	}
+}
+
+// Enqueue queues a transfer from src to dst and returns its ID
+// immediately; the transfer itself runs in the background once a
+// concurrency slot is free. If dst already exists, it's overwritten; use
+// EnqueueWithPolicy for other conflict behavior.
+func (m *Manager) Enqueue(direction Direction, src Source, dst Sink) string {
+	return m.enqueue(direction, src, dst, Overwrite, nil)
+}
+
+// EnqueueWithPolicy queues a transfer like Enqueue, but applies policy if
+// dst already exists instead of always overwriting it.
+func (m *Manager) EnqueueWithPolicy(direction Direction, src Source, dst Sink, policy ConflictPolicy) string {
+	return m.enqueue(direction, src, dst, policy, nil)
+}
+
+// EnqueueVerified queues a transfer like Enqueue, but also runs verify
+// once the copy finishes successfully; a non-nil return from verify
+// fails the transfer just like a copy error would.
+func (m *Manager) EnqueueVerified(direction Direction, src Source, dst Sink, verify func() error) string {
+	return m.enqueue(direction, src, dst, Overwrite, verify)
+}
+
+func (m *Manager) enqueue(direction Direction, src Source, dst Sink, policy ConflictPolicy, verify func() error) string {
+	m.mu.Lock()
+	m.nextID++
+	id := fmt.Sprintf("xfer-%d", m.nextID)
+	j := &job{id: id, direction: direction, src: src, dst: dst, policy: policy, state: Queued, verify: verify}
+	j.cond = sync.NewCond(&j.mu)
+	m.jobs[id] = j
+	m.order = append(m.order, id)
+	m.mu.Unlock()
+
+	m.emit(j.progress())
+	go m.run(j)
+	return id
+}
+
+// resolveConflict applies j's ConflictPolicy if its destination already
+// exists, returning true if the transfer should be skipped entirely.
+func (m *Manager) resolveConflict(j *job) (skip bool) {
+	if j.policy == "" || j.policy == Overwrite {
+		return false
+	}
+
+	ex, ok := j.dst.(Exister)
+	if !ok {
+		return false
+	}
+	exists, err := ex.Exists()
+	if err != nil || !exists {
+		return false
+	}
+
+	switch j.policy {
+	case Skip:
+		return true
+	case Rename:
+		if r, ok := j.dst.(Renamer); ok {
+			if next, err := r.NextAvailable(); err == nil {
+				j.dst = next
+			}
+		}
+	}
+	return false
+}
+
+func (m *Manager) run(j *job) {
+	m.sem <- struct{}{}
+	defer func() { <-m.sem }()
+
+	if m.resolveConflict(j) {
+		j.mu.Lock()
+		j.state = Skipped
+		j.mu.Unlock()
+		m.emit(j.progress())
+		return
+	}
+
+	var total int64
+	if sizer, ok := j.src.(Sizer); ok {
+		if n, err := sizer.Size(); err == nil {
+			total = n
+		}
+	}
+
+	j.mu.Lock()
+	j.state = Running
+	j.totalBytes = total
+	j.startedAt = time.Now()
+	j.mu.Unlock()
+	m.emit(j.progress())
+
+	err := m.copy(j)
+	if err == nil && j.verify != nil {
+		err = j.verify()
+	}
+
+	j.mu.Lock()
+	switch {
+	case j.cancelled:
+		j.state = Cancelled
+	case err != nil:
+		j.state = Failed
+		j.err = err
+	default:
+		j.state = Completed
+	}
+	j.mu.Unlock()
+	m.emit(j.progress())
+}
+
+// copy streams src to dst in chunks, checking between each chunk whether
+// the job has been paused (block until resumed) or cancelled (stop). If
+// j already has bytesDone from a previous failed attempt and both ends
+// support resuming, it picks up from there instead of starting over.
+func (m *Manager) copy(j *job) error {
+	j.mu.Lock()
+	resumeFrom := j.bytesDone
+	j.mu.Unlock()
+
+	var r io.ReadCloser
+	var w io.WriteCloser
+	var err error
+
+	offsetSrc, srcResumable := j.src.(OffsetSource)
+	offsetDst, dstResumable := j.dst.(OffsetSink)
+	if resumeFrom > 0 && srcResumable && dstResumable {
+		r, err = offsetSrc.OpenAt(resumeFrom)
+		if err != nil {
+			return fmt.Errorf("resume transfer source: %w", err)
+		}
+		defer r.Close()
+
+		w, err = offsetDst.CreateAt(resumeFrom)
+		if err != nil {
+			return fmt.Errorf("resume transfer destination: %w", err)
+		}
+		defer w.Close()
+	} else {
+		j.mu.Lock()
+		j.bytesDone = 0
+		j.mu.Unlock()
+
+		r, err = j.src.Open()
+		if err != nil {
+			return fmt.Errorf("open transfer source: %w", err)
+		}
+		defer r.Close()
+
+		w, err = j.dst.Create()
+		if err != nil {
+			return fmt.Errorf("open transfer destination: %w", err)
+		}
+		defer w.Close()
+	}
+
+	buf := make([]byte, chunkSize)
+	for {
+		j.mu.Lock()
+		for j.state == Paused && !j.cancelled {
+			j.cond.Wait()
+		}
+		cancelled := j.cancelled
+		j.mu.Unlock()
+		if cancelled {
+			return nil
+		}
+
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			if _, writeErr := w.Write(buf[:n]); writeErr != nil {
+				return fmt.Errorf("write transfer chunk: %w", writeErr)
+			}
+			j.mu.Lock()
+			j.bytesDone += int64(n)
+			j.mu.Unlock()
+			m.emit(j.progress())
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			return fmt.Errorf("read transfer chunk: %w", readErr)
+		}
+	}
+}
+
+func (m *Manager) emit(p Progress) {
+	if m.onProgress != nil {
+		m.onProgress(p)
+	}
+}
+
+func (m *Manager) get(id string) (*job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j, ok := m.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("transfer %q not found", id)
+	}
+	return j, nil
+}
+
+// PauseTransfer pauses a running transfer; its goroutine blocks between
+// chunks until ResumeTransfer or CancelTransfer is called.
+func (m *Manager) PauseTransfer(id string) error {
+	j, err := m.get(id)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.state == Running {
+		j.state = Paused
+	}
+	return nil
+}
+
+// ResumeTransfer resumes a paused transfer.
+func (m *Manager) ResumeTransfer(id string) error {
+	j, err := m.get(id)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.state == Paused {
+		j.state = Running
+		j.cond.Broadcast()
+	}
+	return nil
+}
+
+// CancelTransfer stops a queued, running, or paused transfer; it settles
+// into the Cancelled state once its goroutine notices.
+func (m *Manager) CancelTransfer(id string) error {
+	j, err := m.get(id)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.cancelled = true
+	j.cond.Broadcast()
+	return nil
+}
+
+// ResumeFailedTransfer retries a failed or cancelled transfer. If both
+// its source and destination support resuming, it continues from the
+// bytes already transferred instead of starting over.
+func (m *Manager) ResumeFailedTransfer(id string) error {
+	j, err := m.get(id)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	if j.state != Failed && j.state != Cancelled {
+		j.mu.Unlock()
+		return fmt.Errorf("transfer %q is not failed or cancelled", id)
+	}
+	j.state = Queued
+	j.cancelled = false
+	j.err = nil
+	j.mu.Unlock()
+	m.emit(j.progress())
+
+	go m.run(j)
+	return nil
+}
+
+// ListTransfers returns the current progress of every queued, running,
+// or finished transfer, oldest first.
+func (m *Manager) ListTransfers() []Progress {
+	m.mu.Lock()
+	order := append([]string(nil), m.order...)
+	m.mu.Unlock()
+
+	progress := make([]Progress, 0, len(order))
+	for _, id := range order {
+		j, err := m.get(id)
+		if err != nil {
+			continue
+		}
+		progress = append(progress, j.progress())
+	}
+	return progress
+}
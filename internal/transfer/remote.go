@@ -0,0 +1,88 @@
+package transfer
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"sshapp/internal/remotefs"
+	"sshapp/internal/sshsession"
+)
+
+// RemoteFile is a Source and Sink backed by a path on a remote host,
+// reached over sess's cached SFTP client.
+type RemoteFile struct {
+	Sess *sshsession.Session
+	Path string
+}
+
+// Open implements Source, downloading (following symlinks) from Path.
+// It falls back to `cat` over a raw exec channel on hosts with the SFTP
+// subsystem disabled.
+func (r RemoteFile) Open() (io.ReadCloser, error) {
+	return remotefs.OpenAuto(r.Sess, r.Path)
+}
+
+// Size implements Sizer.
+func (r RemoteFile) Size() (int64, error) {
+	return remotefs.StatSizeAuto(r.Sess, r.Path)
+}
+
+// Create implements Sink, streaming whatever is written to it up to
+// Path via remotefs.WriteAuto.
+func (r RemoteFile) Create() (io.WriteCloser, error) {
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+
+	go func() {
+		done <- remotefs.WriteAuto(r.Sess, r.Path, pr)
+	}()
+
+	return &remoteWriter{pw: pw, done: done}, nil
+}
+
+// Exists implements Exister.
+func (r RemoteFile) Exists() (bool, error) {
+	_, err := remotefs.Stat(r.Sess, r.Path, true)
+	if err != nil {
+		// remotefs.Stat doesn't distinguish "not found" from other
+		// failures, so treat any error as "doesn't exist (or isn't
+		// reachable, which amounts to the same thing for conflict
+		// purposes)".
+		return false, nil
+	}
+	return true, nil
+}
+
+// NextAvailable implements Renamer, trying "name (1).ext", "name (2).ext",
+// and so on until it finds a remote path that doesn't exist yet.
+func (r RemoteFile) NextAvailable() (Sink, error) {
+	dir := path.Dir(r.Path)
+	ext := path.Ext(r.Path)
+	base := strings.TrimSuffix(path.Base(r.Path), ext)
+
+	for i := 1; i < 1000; i++ {
+		candidate := path.Join(dir, fmt.Sprintf("%s (%d)%s", base, i, ext))
+		if _, err := remotefs.Stat(r.Sess, candidate, true); err != nil {
+			return RemoteFile{Sess: r.Sess, Path: candidate}, nil
+		}
+	}
+	return nil, fmt.Errorf("find available name for %q: too many conflicts", r.Path)
+}
+
+type remoteWriter struct {
+	pw   *io.PipeWriter
+	done chan error
+}
+
+func (w *remoteWriter) Write(p []byte) (int, error) {
+	return w.pw.Write(p)
+}
+
+func (w *remoteWriter) Close() error {
+	if err := w.pw.Close(); err != nil {
+		return fmt.Errorf("close remote upload pipe: %w", err)
+	}
+	return <-w.done
+}
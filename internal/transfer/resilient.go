@@ -0,0 +1,70 @@
+package transfer
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Reconnector re-establishes a Source/Sink pair after a connection
+// drops mid-transfer, resuming from offset bytes already written.
+type Reconnector interface {
+	Reconnect(offset int64) (Source, Sink, error)
+}
+
+// RetryPolicy controls how CopyResilient retries after a connection
+// loss.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+}
+
+// DefaultRetryPolicy retries a handful of times with a short pause,
+// enough to ride out a flaky link without hammering it.
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 5, Backoff: 2 * time.Second}
+
+// CopyResilient streams src to dst like Copy, but on a connection-level
+// error it asks reconnector for a fresh Source/Sink pair picking up from
+// where the transfer left off, rather than failing the whole transfer.
+func CopyResilient(src Source, dst Sink, reconnector Reconnector, policy RetryPolicy) (int64, error) {
+	var total int64
+	attempt := 0
+
+	for {
+		n, err := copyOnce(src, dst)
+		total += n
+		if err == nil {
+			return total, nil
+		}
+
+		attempt++
+		if attempt >= policy.MaxAttempts {
+			return total, fmt.Errorf("transfer failed after %d attempts: %w", attempt, err)
+		}
+
+		time.Sleep(policy.Backoff)
+
+		src, dst, err = reconnector.Reconnect(total)
+		if err != nil {
+			return total, fmt.Errorf("reconnect after transfer failure: %w", err)
+		}
+	}
+}
+
+// copyOnce performs a single attempt, returning however many bytes it
+// managed to move before any error.
+func copyOnce(src Source, dst Sink) (int64, error) {
+	r, err := src.Open()
+	if err != nil {
+		return 0, fmt.Errorf("open transfer source: %w", err)
+	}
+	defer r.Close()
+
+	w, err := dst.Create()
+	if err != nil {
+		return 0, fmt.Errorf("open transfer destination: %w", err)
+	}
+	defer w.Close()
+
+	return io.Copy(w, r)
+}
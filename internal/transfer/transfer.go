@@ -0,0 +1,48 @@
+// Package transfer moves files to and from remote hosts over SFTP.
+package transfer
+
+import (
+	"fmt"
+	"io"
+)
+
+// Direction is which way a transfer moves data.
+type Direction string
+
+const (
+	Upload   Direction = "upload"
+	Download Direction = "download"
+	Relay    Direction = "relay" // host-to-host, data passes through this app
+)
+
+// Source opens the data a transfer reads from.
+type Source interface {
+	Open() (io.ReadCloser, error)
+}
+
+// Sink opens the destination a transfer writes to.
+type Sink interface {
+	Create() (io.WriteCloser, error)
+}
+
+// Copy streams everything from src to dst and returns the number of
+// bytes moved.
+func Copy(src Source, dst Sink) (int64, error) {
+	r, err := src.Open()
+	if err != nil {
+		return 0, fmt.Errorf("open transfer source: %w", err)
+	}
+	defer r.Close()
+
+	w, err := dst.Create()
+	if err != nil {
+		return 0, fmt.Errorf("open transfer destination: %w", err)
+	}
+	defer w.Close()
+
+	n, err := io.Copy(w, r)
+	if err != nil {
+		return n, fmt.Errorf("copy transfer: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,183 @@
+// Package vault is a master-password-protected encrypted store for
+// profile secrets (passwords, passphrases, tokens), for users without
+// access to an OS keychain (see internal/keychain for that path). Its
+// file format is a NaCl secretbox wrapped around an scrypt-derived key,
+// so losing the master password means losing the secrets — there is no
+// recovery path by design.
+package vault
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	saltSize  = 32
+	nonceSize = 24
+)
+
+// ErrWrongPassword is returned by Open when masterPassword fails to
+// decrypt an existing vault file.
+var ErrWrongPassword = errors.New("wrong master password")
+
+// Vault holds decrypted secrets in memory, keyed by an opaque
+// reference a profile points at via VaultRef.
+type Vault struct {
+	path    string
+	key     [32]byte
+	salt    []byte
+	secrets map[string]string
+}
+
+type vaultFile struct {
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Open unlocks the vault at path with masterPassword, or creates a new
+// empty one (not yet written to disk until the first Set) if path
+// doesn't exist yet. Returns ErrWrongPassword if path exists but
+// masterPassword doesn't decrypt it.
+func Open(path, masterPassword string) (*Vault, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		salt := make([]byte, saltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, fmt.Errorf("generate vault salt: %w", err)
+		}
+		key, err := deriveKey(masterPassword, salt)
+		if err != nil {
+			return nil, err
+		}
+		return &Vault{path: path, key: key, salt: salt, secrets: make(map[string]string)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read vault %q: %w", path, err)
+	}
+
+	var vf vaultFile
+	if err := json.Unmarshal(data, &vf); err != nil {
+		return nil, fmt.Errorf("parse vault %q: %w", path, err)
+	}
+
+	key, err := deriveKey(masterPassword, vf.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce [nonceSize]byte
+	copy(nonce[:], vf.Nonce)
+	plaintext, ok := secretbox.Open(nil, vf.Ciphertext, &nonce, &key)
+	if !ok {
+		return nil, ErrWrongPassword
+	}
+
+	secrets := make(map[string]string)
+	if len(plaintext) > 0 {
+		if err := json.Unmarshal(plaintext, &secrets); err != nil {
+			return nil, fmt.Errorf("parse vault %q contents: %w", path, err)
+		}
+	}
+
+	return &Vault{path: path, key: key, salt: vf.Salt, secrets: secrets}, nil
+}
+
+func deriveKey(password string, salt []byte) ([32]byte, error) {
+	var key [32]byte
+	derived, err := scrypt.Key([]byte(password), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return key, fmt.Errorf("derive vault key: %w", err)
+	}
+	copy(key[:], derived)
+	return key, nil
+}
+
+// Get returns ref's secret, if any.
+func (v *Vault) Get(ref string) (string, bool) {
+	val, ok := v.secrets[ref]
+	return val, ok
+}
+
+// Set stores value under ref and persists the vault to disk.
+func (v *Vault) Set(ref, value string) error {
+	v.secrets[ref] = value
+	return v.save()
+}
+
+// Delete removes ref's secret, if any, and persists the vault to disk.
+func (v *Vault) Delete(ref string) error {
+	delete(v.secrets, ref)
+	return v.save()
+}
+
+// EncryptBytes encrypts plaintext under a key derived from password,
+// using the same scrypt+secretbox format as the vault file itself. It's
+// exported for other packages that want password-based encryption for
+// a one-off blob (e.g. an exported profile bundle) without standing up
+// a whole Vault.
+func EncryptBytes(password string, plaintext []byte) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+	key, err := deriveKey(password, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce [nonceSize]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	ciphertext := secretbox.Seal(nil, plaintext, &nonce, &key)
+
+	return json.Marshal(vaultFile{Salt: salt, Nonce: nonce[:], Ciphertext: ciphertext})
+}
+
+// DecryptBytes reverses EncryptBytes, returning ErrWrongPassword if
+// password doesn't decrypt sealed.
+func DecryptBytes(password string, sealed []byte) ([]byte, error) {
+	var vf vaultFile
+	if err := json.Unmarshal(sealed, &vf); err != nil {
+		return nil, fmt.Errorf("parse sealed data: %w", err)
+	}
+
+	key, err := deriveKey(password, vf.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce [nonceSize]byte
+	copy(nonce[:], vf.Nonce)
+	plaintext, ok := secretbox.Open(nil, vf.Ciphertext, &nonce, &key)
+	if !ok {
+		return nil, ErrWrongPassword
+	}
+	return plaintext, nil
+}
+
+func (v *Vault) save() error {
+	plaintext, err := json.Marshal(v.secrets)
+	if err != nil {
+		return fmt.Errorf("encode vault: %w", err)
+	}
+
+	var nonce [nonceSize]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return fmt.Errorf("generate vault nonce: %w", err)
+	}
+	ciphertext := secretbox.Seal(nil, plaintext, &nonce, &v.key)
+
+	data, err := json.Marshal(vaultFile{Salt: v.salt, Nonce: nonce[:], Ciphertext: ciphertext})
+	if err != nil {
+		return fmt.Errorf("encode vault file: %w", err)
+	}
+	return os.WriteFile(v.path, data, 0o600)
+}
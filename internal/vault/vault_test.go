@@ -0,0 +1,102 @@
+package vault
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenCreatesThenReopens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vault.json")
+
+	v, err := Open(path, "correct-password")
+	if err != nil {
+		t.Fatalf("Open (create): %v", err)
+	}
+	if err := v.Set("ref1", "s3cret"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	reopened, err := Open(path, "correct-password")
+	if err != nil {
+		t.Fatalf("Open (reopen): %v", err)
+	}
+	got, ok := reopened.Get("ref1")
+	if !ok || got != "s3cret" {
+		t.Fatalf("Get after reopen = %q, %v, want %q, true", got, ok, "s3cret")
+	}
+}
+
+func TestOpenWrongPassword(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vault.json")
+
+	v, err := Open(path, "correct-password")
+	if err != nil {
+		t.Fatalf("Open (create): %v", err)
+	}
+	if err := v.Set("ref1", "s3cret"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	_, err = Open(path, "wrong-password")
+	if !errors.Is(err, ErrWrongPassword) {
+		t.Fatalf("Open with wrong password: got %v, want ErrWrongPassword", err)
+	}
+}
+
+func TestDeleteRemovesSecret(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vault.json")
+
+	v, err := Open(path, "pw")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := v.Set("ref1", "s3cret"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := v.Delete("ref1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok := v.Get("ref1"); ok {
+		t.Fatalf("Get after Delete: secret still present")
+	}
+}
+
+func TestEncryptDecryptBytesRoundTrip(t *testing.T) {
+	plaintext := []byte("a bundle manifest's worth of bytes")
+
+	sealed, err := EncryptBytes("bundle-password", plaintext)
+	if err != nil {
+		t.Fatalf("EncryptBytes: %v", err)
+	}
+
+	got, err := DecryptBytes("bundle-password", sealed)
+	if err != nil {
+		t.Fatalf("DecryptBytes: %v", err)
+	}
+	if string(got) != string(plaintext) {
+		t.Fatalf("DecryptBytes = %q, want %q", got, plaintext)
+	}
+
+	if _, err := DecryptBytes("wrong-password", sealed); !errors.Is(err, ErrWrongPassword) {
+		t.Fatalf("DecryptBytes with wrong password: got %v, want ErrWrongPassword", err)
+	}
+}
+
+// deriveKey must actually mix the salt in — this is the exact class of
+// regression a prior change (swapping the KDF for plain SHA-256) slipped
+// past: two different salts produced the same key because the salt
+// never made it into the digest.
+func TestDeriveKeyDependsOnSalt(t *testing.T) {
+	key1, err := deriveKey("same-password", []byte("salt-one-salt-one-salt-one-salt"))
+	if err != nil {
+		t.Fatalf("deriveKey: %v", err)
+	}
+	key2, err := deriveKey("same-password", []byte("salt-two-salt-two-salt-two-salt"))
+	if err != nil {
+		t.Fatalf("deriveKey: %v", err)
+	}
+	if key1 == key2 {
+		t.Fatalf("deriveKey produced the same key for two different salts")
+	}
+}